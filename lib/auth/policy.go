@@ -0,0 +1,227 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// Returned when the policy file is malformed
+	ErrInvalidPolicy = errors.New("invalid auth policy")
+	// Returned when the client didn't present a known token or certificate
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// Returned when the client is not allowed to make a call
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// Who is allowed to call the servers and what they can do. Principals are identified by one of their bearer tokens,
+// or by the common name of their client certificate when the server uses mutual TLS
+type Policy struct {
+	Principals []Principal `yaml:"principals"`
+}
+
+// Client of the servers. Tokens are the SHA-256 hashes of the bearer tokens, in hex, so the policy file doesn't hold
+// the tokens themselves. RPCs are globs such as FileUtils/CompareFile or DockerUtils/*, and * allows every call. Paths
+// are globs of the file names the principal can read or write, where dir/** matches anything in dir, and containers are
+// globs of the container names it can manage. Leaving paths or containers empty doesn't limit them. A container scope
+// only covers managing the containers it matches with their current image, calls that reach every container or the
+// images they share, such as listing the containers, listing or pulling images and choosing the image or tag of a
+// container, need a principal that isn't limited to some containers. Privileged containers, devices, bind mounts,
+// including the config dir, named volumes and the network of the host or of another container reach outside of the
+// container, so they need a principal that isn't limited to some containers or paths
+type Principal struct {
+	Name       string   `yaml:"name"`
+	Tokens     []string `yaml:"tokens"`
+	RPCs       []string `yaml:"rpcs"`
+	Paths      []string `yaml:"paths"`
+	Containers []string `yaml:"containers"`
+}
+
+// This function loads the policy from a YAML file
+func LoadPolicy(file string) (*Policy, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePolicy(content)
+}
+
+// This function parses and validates a YAML policy, unknown fields are rejected so a typo doesn't grant access
+func ParsePolicy(content []byte) (*Policy, error) {
+	var policy Policy
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&policy); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPolicy, err)
+	}
+
+	if err := policy.validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Returns the SHA-256 hash of a token as it's stored in the policy
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Returns the principal that owns the bearer token
+func (p *Policy) Authenticate(token string) (*Principal, error) {
+	hash := []byte(HashToken(token))
+	for i := range p.Principals {
+		for _, candidate := range p.Principals[i].Tokens {
+			if subtle.ConstantTimeCompare(hash, []byte(strings.ToLower(candidate))) == 1 {
+				return &p.Principals[i], nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: unknown token", ErrUnauthenticated)
+}
+
+// Returns the principal with the name, it's used to find the principal of a client certificate
+func (p *Policy) Principal(name string) (*Principal, error) {
+	for i := range p.Principals {
+		if p.Principals[i].Name == name {
+			return &p.Principals[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s is not in the policy", ErrPermissionDenied, name)
+}
+
+// Checks if the principal can make the call, the method is the full gRPC method such as /FileUtils/SendFile
+func (pr *Principal) AllowRPC(method string) error {
+	method = strings.TrimPrefix(method, "/")
+	for _, pattern := range pr.RPCs {
+		if pattern == "*" {
+			return nil
+		}
+
+		if matched, _ := path.Match(pattern, method); matched {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s can't call %s", ErrPermissionDenied, pr.Name, method)
+}
+
+// Checks if the principal can read or write the file, the name is relative to the file root
+func (pr *Principal) AllowPath(fileName string) error {
+	if len(pr.Paths) == 0 {
+		return nil
+	}
+
+	fileName = strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(fileName, "\\", "/")), "/")
+	if fileName != "" {
+		for _, pattern := range pr.Paths {
+			if matchGlob(pattern, fileName) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: %s can't access the file %q", ErrPermissionDenied, pr.Name, fileName)
+}
+
+// Checks if the principal can manage the container
+func (pr *Principal) AllowContainer(containerName string) error {
+	if len(pr.Containers) == 0 {
+		return nil
+	}
+
+	if containerName != "" {
+		for _, pattern := range pr.Containers {
+			if matchGlob(pattern, containerName) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: %s can't manage the container %q", ErrPermissionDenied, pr.Name, containerName)
+}
+
+// Checks if the principal is limited to some containers
+func (pr *Principal) LimitedContainers() bool {
+	return len(pr.Containers) > 0
+}
+
+// Checks if the principal can make a call that reaches every container, the action describes the call in the error
+func (pr *Principal) AllowEveryContainer(action string) error {
+	if pr.LimitedContainers() {
+		return fmt.Errorf("%w: %s is limited to some containers and can't %s", ErrPermissionDenied, pr.Name, action)
+	}
+
+	return nil
+}
+
+// Checks if the principal can give a container access to the host, the action describes the access in the error
+func (pr *Principal) AllowHost(action string) error {
+	if pr.LimitedContainers() || len(pr.Paths) > 0 {
+		return fmt.Errorf("%w: %s is limited to some containers or paths and can't %s", ErrPermissionDenied, pr.Name, action)
+	}
+
+	return nil
+}
+
+// Checks that every principal has a unique name, that the tokens are SHA-256 hashes and that the globs are valid
+func (p *Policy) validate() error {
+	names := map[string]bool{}
+	for _, principal := range p.Principals {
+		if principal.Name == "" {
+			return fmt.Errorf("%w: a principal is missing its name", ErrInvalidPolicy)
+		}
+
+		if names[principal.Name] {
+			return fmt.Errorf("%w: principal %s is defined twice", ErrInvalidPolicy, principal.Name)
+		}
+		names[principal.Name] = true
+
+		for _, token := range principal.Tokens {
+			if decoded, err := hex.DecodeString(token); err != nil || len(decoded) != sha256.Size {
+				return fmt.Errorf("%w: the tokens of %s must be SHA-256 hashes in hex", ErrInvalidPolicy, principal.Name)
+			}
+		}
+
+		for _, pattern := range append(append(append([]string{}, principal.RPCs...), principal.Paths...), principal.Containers...) {
+			if _, err := path.Match(strings.TrimSuffix(pattern, "/**"), ""); err != nil {
+				return fmt.Errorf("%w: invalid glob %q of %s", ErrInvalidPolicy, pattern, principal.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Matches a name against a glob, a glob ending in /** matches everything inside the directory
+func matchGlob(pattern string, name string) bool {
+	if dir := strings.TrimSuffix(pattern, "/**"); dir != pattern {
+		for i := range name {
+			if name[i] == '/' {
+				if matched, _ := path.Match(dir, name[:i]); matched {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	matched, _ := path.Match(pattern, name)
+	return matched
+}
@@ -0,0 +1,255 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// Validity of the local CA, it's long lived so the clients don't have to trust a new one often
+	caValidity = 10 * 365 * 24 * time.Hour
+	// Validity of the certificates issued by the local CA
+	certValidity = 2 * 365 * 24 * time.Hour
+)
+
+// Returned when a certificate or key can't be used
+var ErrInvalidCertificate = errors.New("invalid certificate")
+
+// Who the certificates issued by a CA are for
+type Usage int
+
+const (
+	ServerUsage Usage = iota
+	ClientUsage
+)
+
+// Certificate authority used to issue the certificates of the server and its clients
+type Authority struct {
+	Certificate *x509.Certificate
+	Key         *ecdsa.PrivateKey
+}
+
+// Files of a local CA along with a certificate for the server and one for a client
+type Bundle struct {
+	CACert     string
+	CAKey      string
+	ServerCert string
+	ServerKey  string
+	ClientCert string
+	ClientKey  string
+}
+
+// This function creates a self-signed CA valid for the duration
+func NewCA(commonName string, validity time.Duration) (*Authority, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := serialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Authority{Certificate: certificate, Key: key}, nil
+}
+
+// This function loads a CA from its PEM encoded certificate and key
+func LoadCA(certFile string, keyFile string) (*Authority, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil || certBlock.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("%w: %s is not a PEM certificate", ErrInvalidCertificate, certFile)
+	}
+
+	certificate, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCertificate, err)
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, fmt.Errorf("%w: %s is not a PEM key", ErrInvalidCertificate, keyFile)
+	}
+
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCertificate, err)
+	}
+
+	if !certificate.IsCA {
+		return nil, fmt.Errorf("%w: %s is not a CA", ErrInvalidCertificate, certFile)
+	}
+
+	return &Authority{Certificate: certificate, Key: key}, nil
+}
+
+// Issues a certificate signed by the CA and returns it along with its key, both PEM encoded. The hosts are IPs or DNS
+// names the certificate is valid for, client certificates identify the client by their common name
+func (a *Authority) Issue(commonName string, hosts []string, usage Usage, validity time.Duration) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := serialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	if usage == ClientUsage {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	}
+
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, a.Certificate, &key.PublicKey, a.Key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyPEM, err := encodeKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), keyPEM, nil
+}
+
+// Writes the certificate and key of the CA, the key is only readable by the owner
+func (a *Authority) Write(certFile string, keyFile string) error {
+	keyPEM, err := encodeKey(a.Key)
+	if err != nil {
+		return err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: a.Certificate.Raw})
+	return writePair(certFile, certPEM, keyFile, keyPEM)
+}
+
+// This function sets up a local CA for home setups in the directory, along with a certificate for the server valid for
+// the hosts and one for a client. If the directory already has a CA it's used to issue the certificates, so clients
+// that trust it keep working
+func GenerateLocalCA(dir string, hosts []string) (Bundle, error) {
+	bundle := Bundle{
+		CACert:     filepath.Join(dir, "ca.pem"),
+		CAKey:      filepath.Join(dir, "ca-key.pem"),
+		ServerCert: filepath.Join(dir, "server.pem"),
+		ServerKey:  filepath.Join(dir, "server-key.pem"),
+		ClientCert: filepath.Join(dir, "client.pem"),
+		ClientKey:  filepath.Join(dir, "client-key.pem"),
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return bundle, err
+	}
+
+	ca, err := LoadCA(bundle.CACert, bundle.CAKey)
+	if errors.Is(err, os.ErrNotExist) {
+		ca, err = NewCA("ha-utils local CA", caValidity)
+		if err == nil {
+			err = ca.Write(bundle.CACert, bundle.CAKey)
+		}
+	}
+	if err != nil {
+		return bundle, err
+	}
+
+	certPEM, keyPEM, err := ca.Issue("ha-utils", hosts, ServerUsage, certValidity)
+	if err != nil {
+		return bundle, err
+	}
+
+	if err := writePair(bundle.ServerCert, certPEM, bundle.ServerKey, keyPEM); err != nil {
+		return bundle, err
+	}
+
+	certPEM, keyPEM, err = ca.Issue("ha-utils-client", nil, ClientUsage, certValidity)
+	if err != nil {
+		return bundle, err
+	}
+
+	return bundle, writePair(bundle.ClientCert, certPEM, bundle.ClientKey, keyPEM)
+}
+
+// Writes a certificate and its key, the key is only readable by the owner
+func writePair(certFile string, certPEM []byte, keyFile string, keyPEM []byte) error {
+	if err := os.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		return err
+	}
+
+	return os.WriteFile(certFile, certPEM, 0644)
+}
+
+// PEM encodes an EC private key
+func encodeKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+}
+
+// Returns a random serial number for a certificate
+func serialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
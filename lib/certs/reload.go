@@ -0,0 +1,182 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// How often the files are checked for changes unless the reloader sets it
+const defaultReloadInterval = 30 * time.Second
+
+// Keeps the TLS configuration of the server in sync with the certificate, key and client CA bundle on disk, so they
+// can be renewed without restarting the server. Connections that are already open keep the certificate they started
+// with
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	interval     time.Duration
+
+	mu       sync.RWMutex
+	config   *tls.Config
+	modTimes map[string]time.Time
+}
+
+// Option used to configure the reloader
+type Option func(*Reloader)
+
+// Requires the clients to present a certificate signed by one of the CAs in the bundle, which enables mutual TLS
+func WithClientCA(caFile string) Option {
+	return func(r *Reloader) {
+		r.clientCAFile = caFile
+	}
+}
+
+// Sets how often the files are checked for changes
+func WithReloadInterval(interval time.Duration) Option {
+	return func(r *Reloader) {
+		r.interval = interval
+	}
+}
+
+// This function loads the certificate and key of the server, and the client CA bundle if it's set. It fails if they
+// can't be used, later reloads that fail keep the previous ones
+func NewReloader(certFile string, keyFile string, opts ...Option) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile, interval: defaultReloadInterval}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Loads the files again, the current configuration is kept if they can't be used
+func (r *Reloader) Reload() error {
+	modTimes, err := r.stat()
+	if err != nil {
+		return err
+	}
+
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCertificate, err)
+	}
+
+	// The configuration replaces the one of the server during the handshake, so it must negotiate HTTP/2 itself
+	config := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"h2"},
+	}
+
+	if r.clientCAFile != "" {
+		bundle, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(bundle) {
+			return fmt.Errorf("%w: %s has no PEM certificates", ErrInvalidCertificate, r.clientCAFile)
+		}
+
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.config = config
+	r.modTimes = modTimes
+	return nil
+}
+
+// Checks the files for changes until the context is cancelled, reloading them when they change. Certificates are
+// often renewed by writing the key and certificate one after the other, so a pair that doesn't match is retried on
+// the next check
+func (r *Reloader) Watch(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		modTimes, err := r.stat()
+		if err != nil {
+			log.Printf("Unable to check the TLS certificates: %v", err)
+			continue
+		}
+
+		if !r.changed(modTimes) {
+			continue
+		}
+
+		if err := r.Reload(); err != nil {
+			log.Printf("Unable to reload the TLS certificates, the previous ones are kept: %v", err)
+			continue
+		}
+
+		log.Printf("Reloaded the TLS certificates")
+	}
+}
+
+// Returns the TLS configuration of the server, every new connection uses the files loaded last
+func (r *Reloader) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			return r.config, nil
+		},
+	}
+}
+
+// Returns the modification times of the files
+func (r *Reloader) stat() (map[string]time.Time, error) {
+	modTimes := map[string]time.Time{}
+	for _, file := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if file == "" {
+			continue
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+
+		modTimes[file] = info.ModTime()
+	}
+
+	return modTimes, nil
+}
+
+// Checks if any of the files changed since they were loaded
+func (r *Reloader) changed(modTimes map[string]time.Time) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for file, modTime := range modTimes {
+		if !modTime.Equal(r.modTimes[file]) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,370 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aacuadras/ha-utils/lib/docker"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Prefix of the environment variables of the settings, such as HA_UTILS_LISTEN_ADDRESS
+	envPrefix = "HA_UTILS_"
+
+	ServiceDocker     = "docker"
+	ServiceFiles      = "files"
+	ServiceReflection = "reflection"
+)
+
+// Returned when the configuration can't be used, the message lists every problem found
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+// Levels of the server logs
+var logLevels = map[string]bool{
+	"debug": true,
+	"info":  true,
+	"error": true,
+}
+
+// Services that can be enabled
+var services = map[string]bool{
+	ServiceDocker:     true,
+	ServiceFiles:      true,
+	ServiceReflection: true,
+}
+
+// Configuration of the server. It's read from a YAML file, environment variables and flags, in increasing order of
+// precedence
+type Config struct {
+	Listen     Listen     `yaml:"listen"`
+	TLS        TLS        `yaml:"tls"`
+	Auth       Auth       `yaml:"auth"`
+	Files      Files      `yaml:"files"`
+	Containers Containers `yaml:"containers"`
+	// Timezone of the server logs, it's also given to the containers that don't set TZ
+	Timezone string `yaml:"timezone"`
+	// Either debug, which adds the logs of gRPC, info or error, which only logs the errors that stop the server
+	LogLevel string   `yaml:"logLevel"`
+	Services []string `yaml:"services"`
+	// How long the calls in progress have to finish when the server is stopped, such as 30s
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+}
+
+// Where the server listens, a TCP address, a unix socket or both
+type Listen struct {
+	Address string `yaml:"address"`
+	Socket  string `yaml:"socket"`
+}
+
+// Certificate of the server and the CA bundle of the clients, which enables mutual TLS
+type TLS struct {
+	Cert     string `yaml:"cert"`
+	Key      string `yaml:"key"`
+	ClientCA string `yaml:"clientCA"`
+}
+
+type Auth struct {
+	Policy string `yaml:"policy"`
+}
+
+// Settings of the file server, a backup retention of zero keeps every version
+type Files struct {
+	Root            string `yaml:"root"`
+	BackupDir       string `yaml:"backupDir"`
+	BackupRetention int    `yaml:"backupRetention"`
+	CheckContainer  string `yaml:"checkContainer"`
+}
+
+// Settings of the docker server. The host root is the directory containers are allowed to bind mount, the other
+// settings are used when the requests don't set them
+type Containers struct {
+	HostRoot      string `yaml:"hostRoot"`
+	Image         string `yaml:"image"`
+	Tag           string `yaml:"tag"`
+	RestartPolicy string `yaml:"restartPolicy"`
+	NetworkMode   string `yaml:"networkMode"`
+}
+
+// Setting that can be set with a flag or environment variable, the environment variable is named after the flag
+type setting struct {
+	flag  string
+	usage string
+	set   func(c *Config, value string) error
+}
+
+// Flags of the configuration along with the one of the config file
+type Flags struct {
+	configFile *string
+	values     map[string]*string
+	fs         *flag.FlagSet
+}
+
+// Every setting that can be set with a flag or environment variable
+var settings = []setting{
+	{"listen-address", "TCP address the server listens on, empty to only listen on the unix socket", func(c *Config, v string) error { c.Listen.Address = v; return nil }},
+	{"listen-socket", "Unix socket the server listens on", func(c *Config, v string) error { c.Listen.Socket = v; return nil }},
+	{"tls-cert", "Certificate of the server, the connections are only encrypted when it's set", func(c *Config, v string) error { c.TLS.Cert = v; return nil }},
+	{"tls-key", "Key of the server certificate", func(c *Config, v string) error { c.TLS.Key = v; return nil }},
+	{"tls-client-ca", "CA bundle used to verify the certificates of the clients, which are required when it's set", func(c *Config, v string) error { c.TLS.ClientCA = v; return nil }},
+	{"auth-policy", "Policy file with the principals allowed to call the server, every client is allowed when it's not set", func(c *Config, v string) error { c.Auth.Policy = v; return nil }},
+	{"file-root", "Directory that the files sent by the clients are confined to", func(c *Config, v string) error { c.Files.Root = v; return nil }},
+	{"backup-dir", "Directory where the previous versions of the files are stored, defaults to .ha-utils/backups in the file root", func(c *Config, v string) error { c.Files.BackupDir = v; return nil }},
+	{"backup-retention", "Number of previous versions kept for every file, zero keeps all of them", func(c *Config, v string) error {
+		retention, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("backup retention %q is not a number", v)
+		}
+		c.Files.BackupRetention = retention
+		return nil
+	}},
+	{"check-container", "Home assistant container used to check the configuration of the files that must be validated", func(c *Config, v string) error { c.Files.CheckContainer = v; return nil }},
+	{"host-root", "Directory of the host that containers are allowed to bind mount", func(c *Config, v string) error { c.Containers.HostRoot = v; return nil }},
+	{"container-image", "Image of the containers whose requests don't set one", func(c *Config, v string) error { c.Containers.Image = v; return nil }},
+	{"container-tag", "Tag of the default image", func(c *Config, v string) error { c.Containers.Tag = v; return nil }},
+	{"restart-policy", "Restart policy of the containers whose requests don't set one", func(c *Config, v string) error { c.Containers.RestartPolicy = v; return nil }},
+	{"network-mode", "Network of the containers whose requests don't set one", func(c *Config, v string) error { c.Containers.NetworkMode = v; return nil }},
+	{"timezone", "Timezone of the logs and of the containers that don't set TZ", func(c *Config, v string) error { c.Timezone = v; return nil }},
+	{"log-level", "Level of the logs, either debug, info or error", func(c *Config, v string) error { c.LogLevel = v; return nil }},
+	{"services", "Comma separated services to enable: docker, files and reflection", func(c *Config, v string) error { c.Services = splitList(v); return nil }},
+	{"shutdown-timeout", "How long the calls in progress have to finish when the server is stopped, such as 30s", func(c *Config, v string) error {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("shutdown timeout %q is not a duration", v)
+		}
+		c.ShutdownTimeout = timeout
+		return nil
+	}},
+}
+
+// Returns the configuration used when nothing is set, the server listens on localhost:8080 with every service enabled
+func Default() *Config {
+	return &Config{
+		Listen:   Listen{Address: "localhost:8080"},
+		Files:    Files{Root: ".", BackupRetention: 10},
+		Timezone: "America/Chicago",
+		LogLevel: "info",
+		Services: []string{ServiceDocker, ServiceFiles, ServiceReflection},
+
+		ShutdownTimeout: 30 * time.Second,
+	}
+}
+
+// This function registers the flags of every setting and the one of the config file in the flag set
+func NewFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{
+		configFile: fs.String("config", "", "YAML configuration file, it can also be set with "+envName("config")),
+		values:     map[string]*string{},
+		fs:         fs,
+	}
+
+	for _, s := range settings {
+		f.values[s.flag] = fs.String(s.flag, "", fmt.Sprintf("%s (%s)", s.usage, envName(s.flag)))
+	}
+
+	return f
+}
+
+// This function builds the configuration once the flags are parsed. The defaults are overridden by the config file,
+// then by the environment variables and then by the flags that were set. The result is validated
+func (f *Flags) Load(getenv func(string) string) (*Config, error) {
+	config := Default()
+
+	configFile := *f.configFile
+	if configFile == "" {
+		configFile = getenv(envName("config"))
+	}
+
+	if configFile != "" {
+		content, err := os.ReadFile(configFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+
+		if err := parse(content, config); err != nil {
+			return nil, err
+		}
+	}
+
+	flagsSet := map[string]bool{}
+	f.fs.Visit(func(fl *flag.Flag) {
+		flagsSet[fl.Name] = true
+	})
+
+	var problems []string
+	for _, s := range settings {
+		if value := getenv(envName(s.flag)); value != "" {
+			if err := s.set(config, value); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", envName(s.flag), err))
+			}
+		}
+	}
+
+	for _, s := range settings {
+		if flagsSet[s.flag] {
+			if err := s.set(config, *f.values[s.flag]); err != nil {
+				problems = append(problems, fmt.Sprintf("-%s: %v", s.flag, err))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, invalidConfig(problems)
+	}
+
+	return config, config.Validate()
+}
+
+// This function parses a YAML configuration on top of the defaults without validating it, unknown fields are rejected
+// so typos don't go unnoticed
+func Parse(content []byte) (*Config, error) {
+	config := Default()
+	if err := parse(content, config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// Checks every setting and returns all the problems found at once
+func (c *Config) Validate() error {
+	var problems []string
+	problem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if c.Listen.Address == "" && c.Listen.Socket == "" {
+		problem("listen: an address or a unix socket is required")
+	}
+
+	if c.Listen.Address != "" {
+		if _, port, err := net.SplitHostPort(c.Listen.Address); err != nil {
+			problem("listen.address: %v", err)
+		} else if number, err := strconv.Atoi(port); err != nil || number < 0 || number > 65535 {
+			problem("listen.address: invalid port %q", port)
+		}
+	}
+
+	if c.Listen.Socket != "" && !filepath.IsAbs(c.Listen.Socket) {
+		problem("listen.socket: %q must be an absolute path", c.Listen.Socket)
+	}
+
+	if (c.TLS.Cert == "") != (c.TLS.Key == "") {
+		problem("tls: the certificate and key must be set together")
+	}
+
+	if c.TLS.ClientCA != "" && c.TLS.Cert == "" {
+		problem("tls.clientCA: the client CA requires the certificate and key of the server")
+	}
+
+	for name, file := range map[string]string{"tls.cert": c.TLS.Cert, "tls.key": c.TLS.Key, "tls.clientCA": c.TLS.ClientCA, "auth.policy": c.Auth.Policy} {
+		if file != "" {
+			if _, err := os.Stat(file); err != nil {
+				problem("%s: %v", name, err)
+			}
+		}
+	}
+
+	if info, err := os.Stat(c.Files.Root); err != nil {
+		problem("files.root: %v", err)
+	} else if !info.IsDir() {
+		problem("files.root: %s is not a directory", c.Files.Root)
+	}
+
+	if c.Files.BackupRetention < 0 {
+		problem("files.backupRetention: it can't be negative")
+	}
+
+	if c.Containers.HostRoot != "" && !filepath.IsAbs(c.Containers.HostRoot) {
+		problem("containers.hostRoot: %q must be an absolute path", c.Containers.HostRoot)
+	}
+
+	if c.Containers.Tag != "" && c.Containers.Image == "" {
+		problem("containers.tag: it requires the image")
+	}
+
+	if err := docker.ValidateSettings(&docker.Settings{RestartPolicy: c.Containers.RestartPolicy}, ""); err != nil {
+		problem("containers.restartPolicy: %v", err)
+	}
+
+	if _, err := time.LoadLocation(c.Timezone); err != nil || c.Timezone == "" {
+		problem("timezone: unknown timezone %q", c.Timezone)
+	}
+
+	if !logLevels[c.LogLevel] {
+		problem("logLevel: %q must be debug, info or error", c.LogLevel)
+	}
+
+	if c.ShutdownTimeout <= 0 {
+		problem("shutdownTimeout: it must be positive")
+	}
+
+	if len(c.Services) == 0 {
+		problem("services: at least one service must be enabled")
+	}
+
+	for _, service := range c.Services {
+		if !services[service] {
+			problem("services: unknown service %q", service)
+		}
+	}
+
+	if len(problems) > 0 {
+		return invalidConfig(problems)
+	}
+
+	return nil
+}
+
+// Checks if the service is enabled
+func (c *Config) Enabled(service string) bool {
+	for _, s := range c.Services {
+		if s == service {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Decodes the YAML configuration on top of the config
+func parse(content []byte, config *Config) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+
+	return nil
+}
+
+// Joins the problems in a single error, one per line
+func invalidConfig(problems []string) error {
+	return fmt.Errorf("%w:\n  %s", ErrInvalidConfig, strings.Join(problems, "\n  "))
+}
+
+// Returns the environment variable of a setting, such as HA_UTILS_LISTEN_ADDRESS for listen-address
+func envName(flagName string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// Splits a comma separated list ignoring the spaces around the items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,178 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// Container engine serving the docker API
+type Backend string
+
+const (
+	BackendDocker Backend = "docker"
+	// Podman serves a docker compatible API, but it names its default network differently and treats some restart
+	// policies the same way
+	BackendPodman Backend = "podman"
+)
+
+// Sockets checked, in order, when DOCKER_HOST is not set. The rootless podman socket lives in the runtime directory of
+// the user running the server
+var socketCandidates = []string{
+	"/var/run/docker.sock",
+	filepath.Join("$XDG_RUNTIME_DIR", "podman", "podman.sock"),
+	"/run/podman/podman.sock",
+}
+
+// Information about the container engine used by a runtime
+type RuntimeInfo struct {
+	Backend    Backend
+	Version    string
+	APIVersion string
+	// Address of the engine, such as unix:///var/run/docker.sock
+	Host     string
+	Rootless bool
+}
+
+// This function returns the address of the first docker or podman socket found in the machine, or an empty string if
+// there are none, in which case the default docker address is used. DOCKER_HOST always takes precedence over it
+func DetectSocket() string {
+	for _, candidate := range socketCandidates {
+		path := os.ExpandEnv(candidate)
+		if strings.HasPrefix(path, "/") {
+			if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+				return "unix://" + path
+			}
+		}
+	}
+
+	return ""
+}
+
+// Returns the network containers are attached to when the settings don't specify one. Podman calls its default bridge
+// network podman, so asking for bridge uses it as well
+func defaultNetwork(networkMode string, backend Backend) string {
+	if networkMode != "" && networkMode != "bridge" {
+		return networkMode
+	}
+
+	if backend == BackendPodman {
+		return "podman"
+	}
+
+	return "bridge"
+}
+
+// Converts a restart policy like on-failure:3 to the one used by the backend. Podman treats unless-stopped the same
+// way as always, and older versions reject it, so always is used instead
+func restartPolicy(policy string, backend Backend) (container.RestartPolicy, error) {
+	restart, err := parseRestartPolicy(policy)
+	if err != nil {
+		return restart, err
+	}
+
+	if backend == BackendPodman && restart.Name == "unless-stopped" {
+		restart.Name = "always"
+	}
+
+	return restart, nil
+}
+
+// Parses a restart policy like on-failure:3, containers are restarted unless they are stopped by default. Every
+// backend accepts the same policies, the differences between them are handled by restartPolicy
+func parseRestartPolicy(policy string) (container.RestartPolicy, error) {
+	if policy == "" {
+		policy = "unless-stopped"
+	}
+
+	name, retries, hasRetries := strings.Cut(policy, ":")
+	restart := container.RestartPolicy{Name: name}
+
+	switch name {
+	case "no", "always", "unless-stopped":
+		if hasRetries {
+			return restart, fmt.Errorf("%w: restart policy %s doesn't take a retry count", ErrInvalidSettings, name)
+		}
+	case "on-failure":
+		if hasRetries {
+			count, err := strconv.Atoi(retries)
+			if err != nil || count < 0 {
+				return restart, fmt.Errorf("%w: invalid retry count %q", ErrInvalidSettings, retries)
+			}
+			restart.MaximumRetryCount = count
+		}
+	default:
+		return restart, fmt.Errorf("%w: unknown restart policy %q", ErrInvalidSettings, policy)
+	}
+
+	return restart, nil
+}
+
+// Returns the backend and version of the engine, it's only requested once. Both the versions and the API version
+// negotiated by the client are kept until the process exits, even if the engine is upgraded in the meantime
+func (e *Engine) Info(ctx context.Context) (RuntimeInfo, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.info != nil {
+		return *e.info, nil
+	}
+
+	client, err := e.createClient()
+	if err != nil {
+		return RuntimeInfo{}, err
+	}
+
+	defer client.Close()
+
+	version, err := client.ServerVersion(ctx)
+	if err != nil {
+		return RuntimeInfo{}, err
+	}
+
+	engineInfo, err := client.Info(ctx)
+	if err != nil {
+		return RuntimeInfo{}, err
+	}
+
+	info := RuntimeInfo{
+		Backend:    BackendDocker,
+		Version:    version.Version,
+		APIVersion: client.ClientVersion(),
+		Host:       client.DaemonHost(),
+	}
+
+	for _, component := range version.Components {
+		if strings.Contains(strings.ToLower(component.Name), "podman") {
+			info.Backend = BackendPodman
+			info.Version = component.Version
+		}
+	}
+
+	for _, option := range engineInfo.SecurityOptions {
+		if option == "name=rootless" {
+			info.Rootless = true
+		}
+	}
+
+	e.info = &info
+	return info, nil
+}
+
+// Checks that the daemon answers, unlike the info of the engine it's never cached
+func (e *Engine) Ping(ctx context.Context) error {
+	client, err := e.createClient()
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	_, err = client.Ping(ctx)
+	return err
+}
@@ -2,69 +2,81 @@ package docker
 
 import (
 	"context"
+	"fmt"
 	"log"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
-// Stops and deletes the docker container specified in the settings
-func StopContainer(settings *Settings) error {
-	context := context.Background()
-	log.Printf("Stopping container %s...", settings.ContainerName)
+// Stops and deletes the docker container
+func (e *Engine) StopContainer(ctx context.Context, containerName string) error {
+	log.Printf("Stopping container %s...", containerName)
 
-	client, err := createClient()
+	client, err := e.createClient()
 	if err != nil {
 		return err
 	}
 
 	defer client.Close()
 
-	err = client.ContainerStop(context, settings.ContainerName, container.StopOptions{})
+	err = client.ContainerStop(ctx, containerName, container.StopOptions{})
 
 	if err != nil {
-		log.Panic("Unable to stop home assistant container")
-		return err
+		return fmt.Errorf("unable to stop container %s: %w", containerName, err)
 	}
 
-	err = client.ContainerRemove(context, settings.ContainerName, types.ContainerRemoveOptions{
+	err = client.ContainerRemove(ctx, containerName, types.ContainerRemoveOptions{
 		RemoveVolumes: true,
 		Force:         true,
 	})
 
 	if err != nil {
-		log.Panic("Unable to remove container")
-		return err
+		return fmt.Errorf("unable to remove container %s: %w", containerName, err)
 	}
 
-	log.Printf("Successfully stopped container %s!", settings.ContainerName)
+	log.Printf("Successfully stopped container %s!", containerName)
 	return nil
 }
 
-// Starts a docker contaienr with the image specified in the settings being sent in. It returns the ID of the new container
-func StartContainer(settings *Settings, ctx context.Context) (string, error) {
-	client, err := createClient()
+// Starts a docker container with the image specified in the settings being sent in. It returns the ID of the new
+// container. When the settings have a readiness check, it waits for the container to become ready and returns a
+// NotReadyError if it doesn't. Containers that fail to start or to become ready are removed, so starting them again
+// doesn't conflict with their name
+func (e *Engine) StartContainer(ctx context.Context, settings *Settings) (string, error) {
+	client, err := e.createClient()
 	if err != nil {
 		return "", err
 	}
 
 	defer client.Close()
 
-	hostConfig, networkConfig, exposedPorts, err := setContainerSettings()
+	info, err := e.Info(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	hostConfig, networkConfig, exposedPorts, err := setContainerSettings(settings, info.Backend)
 
 	if err != nil {
 		return "", err
 	}
 
 	config := &container.Config{
-		Image:        settings.ImageName,
+		Image:        settings.Image(),
 		Env:          settings.EnvVars,
 		ExposedPorts: exposedPorts,
-		Hostname:     settings.ContainerName,
+		Labels:       settings.Labels,
 	}
 
-	err = pullImage(client, settings.ImageName)
+	// Containers joining the network stack of another container inherit its hostname
+	if !hostConfig.NetworkMode.IsContainer() {
+		config.Hostname = settings.ContainerName
+	}
+
+	err = pullImage(ctx, client, settings.Image(), logPullProgress(settings.Image()))
 	if err != nil {
 		return "", err
 	}
@@ -83,16 +95,47 @@ func StartContainer(settings *Settings, ctx context.Context) (string, error) {
 		return "", err
 	}
 
-	client.ContainerStart(ctx, cont.ID, types.ContainerStartOptions{})
+	err = client.ContainerStart(ctx, cont.ID, types.ContainerStartOptions{})
+	if err != nil {
+		removeFailedContainer(client, settings.ContainerName, cont.ID)
+		return "", err
+	}
 	log.Printf("Container %s Started! (%s)", settings.ContainerName, cont.ID)
 
+	if settings.Readiness != nil {
+		readiness := *settings.Readiness
+		if readiness.Mode == ReadinessHTTP {
+			readiness.URL, err = readinessURL(settings)
+			if err != nil {
+				removeFailedContainer(client, settings.ContainerName, cont.ID)
+				return "", err
+			}
+		}
+
+		log.Printf("Waiting for container %s to be ready...", settings.ContainerName)
+		if err := waitReady(ctx, client, cont.ID, readiness); err != nil {
+			removeFailedContainer(client, settings.ContainerName, cont.ID)
+			return "", err
+		}
+		log.Printf("Container %s is ready!", settings.ContainerName)
+	}
+
 	return cont.ID, nil
 }
 
+// Removes a container that couldn't be started or didn't become ready along with its anonymous volumes. It's removed
+// even if the request was cancelled, the error that made it fail is the one reported
+func removeFailedContainer(client *client.Client, containerName string, id string) {
+	err := client.ContainerRemove(context.Background(), id, types.ContainerRemoveOptions{RemoveVolumes: true, Force: true})
+	if err != nil {
+		log.Printf("Unable to remove container %s after it failed: %v", containerName, err)
+	}
+}
+
 // Lists the IDs of all the containers that are running in the machine, this is a helper method to test the creation
 // of the containers
-func ListContainerIDs(ctx context.Context) ([]string, error) {
-	client, err := createClient()
+func (e *Engine) ListContainerIDs(ctx context.Context) ([]string, error) {
+	client, err := e.createClient()
 	if err != nil {
 		return []string{}, err
 	}
@@ -113,9 +156,9 @@ func ListContainerIDs(ctx context.Context) ([]string, error) {
 	return containerIds, nil
 }
 
-// Gets the container information based on its ID
-func GetContainer(ctx context.Context, id string) (types.ContainerJSON, error) {
-	client, err := createClient()
+// Gets the container information based on its name or ID
+func (e *Engine) GetContainer(ctx context.Context, id string) (types.ContainerJSON, error) {
+	client, err := e.createClient()
 	if err != nil {
 		return types.ContainerJSON{}, err
 	}
@@ -130,3 +173,15 @@ func GetContainer(ctx context.Context, id string) (types.ContainerJSON, error) {
 
 	return container, nil
 }
+
+// Removes a container even if it's running, its volumes are kept so they can be used by the container that replaces it
+func (e *Engine) RemoveContainer(ctx context.Context, containerName string) error {
+	client, err := e.createClient()
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	return client.ContainerRemove(ctx, containerName, types.ContainerRemoveOptions{Force: true})
+}
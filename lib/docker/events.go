@@ -0,0 +1,158 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// Kind of change in the lifecycle of a container
+type EventType string
+
+const (
+	EventStart        EventType = "start"
+	EventDie          EventType = "die"
+	EventOOM          EventType = "oom"
+	EventHealthStatus EventType = "health_status"
+	EventRestart      EventType = "restart"
+)
+
+// Events reported when no types are requested
+var eventTypes = []EventType{EventStart, EventDie, EventOOM, EventHealthStatus, EventRestart}
+
+// Filters used to watch containers. Names match the exact name or ID of the container and labels are either a key or
+// key=value. Empty filters match every container and every event type
+type WatchOptions struct {
+	Names  []string
+	Labels []string
+	Types  []EventType
+}
+
+// Change in the lifecycle of a container. The exit code is only set when the container dies and the health status
+// when its healthcheck changes
+type ContainerEvent struct {
+	Type          EventType
+	ContainerID   string
+	ContainerName string
+	Image         string
+	ExitCode      int
+	HealthStatus  string
+	Time          time.Time
+}
+
+// This function watches the events of the containers that match the filters and calls the handler with each one as
+// they happen. It only returns once the context is cancelled, the connection with docker is lost or the handler fails
+func (e *Engine) WatchContainers(ctx context.Context, options WatchOptions, handle func(ContainerEvent) error) error {
+	args, err := watchFilters(options)
+	if err != nil {
+		return err
+	}
+
+	client, err := e.createClient()
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	messages, errs := client.Events(ctx, types.EventsOptions{Filters: args})
+	for {
+		select {
+		case message := <-messages:
+			event, ok := containerEvent(message)
+			if !ok {
+				continue
+			}
+
+			if err := handle(event); err != nil {
+				return err
+			}
+		case err := <-errs:
+			return err
+		}
+	}
+}
+
+// Validates the options and converts them to the filters used by the docker API
+func watchFilters(options WatchOptions) (filters.Args, error) {
+	args := filters.NewArgs(filters.Arg("type", string(events.ContainerEventType)))
+
+	for _, name := range options.Names {
+		if name == "" {
+			return args, fmt.Errorf("%w: empty container name", ErrInvalidFilter)
+		}
+		args.Add("container", name)
+	}
+
+	for _, label := range options.Labels {
+		if label == "" || strings.HasPrefix(label, "=") {
+			return args, fmt.Errorf("%w: label filter %q is missing its key", ErrInvalidFilter, label)
+		}
+		args.Add("label", label)
+	}
+
+	requested := options.Types
+	if len(requested) == 0 {
+		requested = eventTypes
+	}
+
+	for _, eventType := range requested {
+		if !isEventType(eventType) {
+			return args, fmt.Errorf("%w: unknown event type %q", ErrInvalidFilter, eventType)
+		}
+		args.Add("event", string(eventType))
+	}
+
+	return args, nil
+}
+
+func isEventType(eventType EventType) bool {
+	for _, known := range eventTypes {
+		if eventType == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Converts a message of the docker API to an event. Health changes come with the status in the action, such as
+// "health_status: healthy"
+func containerEvent(message events.Message) (ContainerEvent, bool) {
+	action, detail, _ := strings.Cut(message.Action, ":")
+	eventType := EventType(action)
+	if message.Type != events.ContainerEventType || !isEventType(eventType) {
+		return ContainerEvent{}, false
+	}
+
+	event := ContainerEvent{
+		Type:          eventType,
+		ContainerID:   message.Actor.ID,
+		ContainerName: message.Actor.Attributes["name"],
+		Image:         message.Actor.Attributes["image"],
+		Time:          time.Unix(0, message.TimeNano).UTC(),
+	}
+
+	if message.TimeNano == 0 {
+		event.Time = time.Unix(message.Time, 0).UTC()
+	}
+
+	if eventType == EventDie {
+		event.ExitCode, _ = strconv.Atoi(message.Actor.Attributes["exitCode"])
+	}
+
+	if eventType == EventHealthStatus {
+		event.HealthStatus = strings.TrimSpace(detail)
+	}
+
+	return event, true
+}
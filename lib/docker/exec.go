@@ -0,0 +1,91 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// Command executed inside a container. With a TTY, stdout and stderr are merged by the terminal and everything is
+// written to stdout
+type ExecOptions struct {
+	Cmd        []string
+	Env        []string
+	WorkingDir string
+	User       string
+	Tty        bool
+}
+
+// Output of a command executed inside a container
+type ExecResult struct {
+	ExitCode int
+	Stdout   string
+	Stderr   string
+}
+
+// Runs a command inside a running container and copies its output to the writers as it's produced. It returns the
+// exit code of the command once it finishes
+func (e *Engine) Exec(ctx context.Context, containerName string, options ExecOptions, stdout io.Writer, stderr io.Writer) (int, error) {
+	client, err := e.createClient()
+	if err != nil {
+		return 0, err
+	}
+
+	defer client.Close()
+
+	exec, err := client.ContainerExecCreate(ctx, containerName, types.ExecConfig{
+		AttachStdout: true,
+		AttachStderr: true,
+		Tty:          options.Tty,
+		Env:          options.Env,
+		WorkingDir:   options.WorkingDir,
+		User:         options.User,
+		Cmd:          options.Cmd,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	attach, err := client.ContainerExecAttach(ctx, exec.ID, types.ExecStartCheck{Tty: options.Tty})
+	if err != nil {
+		return 0, err
+	}
+
+	defer attach.Close()
+
+	// Without a TTY, docker multiplexes stdout and stderr in the same connection
+	if options.Tty {
+		_, err = io.Copy(stdout, attach.Reader)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, attach.Reader)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	inspect, err := client.ContainerExecInspect(ctx, exec.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	return inspect.ExitCode, nil
+}
+
+// Runs a command inside a running container of the runtime and waits for it to finish, the output is returned once
+// the command exits
+func ExecCommand(ctx context.Context, rt Runtime, containerName string, cmd []string) (ExecResult, error) {
+	var stdout, stderr bytes.Buffer
+	exitCode, err := rt.Exec(ctx, containerName, ExecOptions{Cmd: cmd}, &stdout, &stderr)
+	if err != nil {
+		return ExecResult{}, err
+	}
+
+	return ExecResult{
+		ExitCode: exitCode,
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+	}, nil
+}
@@ -0,0 +1,704 @@
+package docker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/errdefs"
+)
+
+// Runtime that keeps the containers in memory, it's used to test the servers without a docker daemon. Containers
+// start right away and are ready as soon as they start, images are pulled instantly and commands executed inside
+// the containers do nothing unless an exec handler is set
+type Fake struct {
+	mu          sync.Mutex
+	containers  map[string]*fakeContainer
+	images      map[string]*fakeImage
+	networks    map[string]map[string]string
+	volumes     map[string]map[string]string
+	watchers    map[*fakeWatcher]bool
+	info        RuntimeInfo
+	pingErr     error
+	startErrs   map[string]error
+	created     int
+	execHandler func(containerName string, options ExecOptions, stdout io.Writer, stderr io.Writer) int
+}
+
+type fakeContainer struct {
+	id        string
+	name      string
+	imageID   string
+	settings  Settings
+	state     string
+	exitCode  int
+	created   time.Time
+	startedAt time.Time
+	stdout    string
+	stderr    string
+	stats     Stats
+}
+
+type fakeImage struct {
+	id      string
+	tags    []string
+	digests []string
+	created time.Time
+	size    int64
+}
+
+type fakeWatcher struct {
+	args   filters.Args
+	events chan ContainerEvent
+}
+
+// Returns an empty fake runtime
+func NewFake() *Fake {
+	return &Fake{
+		containers: map[string]*fakeContainer{},
+		images:     map[string]*fakeImage{},
+		networks:   map[string]map[string]string{},
+		volumes:    map[string]map[string]string{},
+		watchers:   map[*fakeWatcher]bool{},
+		startErrs:  map[string]error{},
+		info:       RuntimeInfo{Backend: BackendDocker, Version: "fake", APIVersion: api.DefaultVersion},
+	}
+}
+
+var _ Runtime = (*Fake)(nil)
+
+// Sets the engine reported by the runtime, the backend changes the settings of the containers started afterwards
+func (f *Fake) SetInfo(info RuntimeInfo) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.info = info
+}
+
+// Sets the error returned when the runtime is pinged, as if the engine couldn't be reached. A nil error makes it
+// reachable again
+func (f *Fake) SetPingError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.pingErr = err
+}
+
+// Makes the next start of the container fail with the error, like the engine the failed container is removed
+func (f *Fake) FailStart(containerName string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.startErrs[containerName] = err
+}
+
+// Adds an image as if it was already pulled, images added later are newer
+func (f *Fake) AddImage(image string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.pull(image)
+}
+
+// Sets the function that runs the commands executed inside the containers, it returns the exit code of the command
+func (f *Fake) SetExecHandler(handler func(containerName string, options ExecOptions, stdout io.Writer, stderr io.Writer) int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.execHandler = handler
+}
+
+// Appends output to the logs of a container
+func (f *Fake) WriteLogs(containerName string, stdout string, stderr string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, err := f.find(containerName)
+	if err != nil {
+		return err
+	}
+
+	c.stdout += stdout
+	c.stderr += stderr
+	return nil
+}
+
+// Sets the resource usage reported for a container
+func (f *Fake) SetStats(containerName string, stats Stats) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, err := f.find(containerName)
+	if err != nil {
+		return err
+	}
+
+	c.stats = stats
+	return nil
+}
+
+// Simulates a container exiting, it's reported to the watchers as a die event
+func (f *Fake) Exit(containerName string, exitCode int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, err := f.find(containerName)
+	if err != nil {
+		return err
+	}
+
+	c.state = "exited"
+	c.exitCode = exitCode
+	f.emit(EventDie, c)
+	return nil
+}
+
+func (f *Fake) StartContainer(ctx context.Context, settings *Settings) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, _, _, err := setContainerSettings(settings, f.info.Backend); err != nil {
+		return "", err
+	}
+
+	if _, err := f.find(settings.ContainerName); err == nil {
+		return "", errdefs.Conflict(fmt.Errorf("the container name %q is already in use", settings.ContainerName))
+	}
+
+	image := f.pull(settings.Image())
+
+	if err, ok := f.startErrs[settings.ContainerName]; ok {
+		delete(f.startErrs, settings.ContainerName)
+		return "", err
+	}
+
+	c := f.newContainer(*settings, image)
+	f.emit(EventStart, c)
+	return c.id, nil
+}
+
+func (f *Fake) StopContainer(ctx context.Context, containerName string) error {
+	return f.RemoveContainer(ctx, containerName)
+}
+
+func (f *Fake) RemoveContainer(ctx context.Context, containerName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, err := f.find(containerName)
+	if err != nil {
+		return err
+	}
+
+	if c.state == "running" {
+		c.state = "exited"
+		f.emit(EventDie, c)
+	}
+
+	delete(f.containers, c.id)
+	return nil
+}
+
+func (f *Fake) RestartContainer(ctx context.Context, containerName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, err := f.find(containerName)
+	if err != nil {
+		return err
+	}
+
+	c.state = "running"
+	c.exitCode = 0
+	c.startedAt = time.Now().UTC()
+	f.emit(EventRestart, c)
+	return nil
+}
+
+func (f *Fake) UpgradeContainer(ctx context.Context, containerName string, options UpgradeOptions) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	current, err := f.find(containerName)
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := upgradeImage(current.settings.Image(), options)
+	if err != nil {
+		return "", err
+	}
+
+	settings := current.settings
+	settings.ImageName = imageName
+	settings.Tag = ""
+	image := f.pull(settings.ImageName)
+
+	current.state = "exited"
+	f.emit(EventDie, current)
+	delete(f.containers, current.id)
+
+	c := f.newContainer(settings, image)
+	f.emit(EventStart, c)
+	return c.id, nil
+}
+
+func (f *Fake) GetContainer(ctx context.Context, containerName string) (types.ContainerJSON, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, err := f.find(containerName)
+	if err != nil {
+		return types.ContainerJSON{}, err
+	}
+
+	hostConfig, _, exposedPorts, err := setContainerSettings(&c.settings, f.info.Backend)
+	if err != nil {
+		return types.ContainerJSON{}, err
+	}
+
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:      c.id,
+			Name:    "/" + c.name,
+			Created: c.created.Format(time.RFC3339Nano),
+			State: &types.ContainerState{
+				Status:    c.state,
+				Running:   c.state == "running",
+				ExitCode:  c.exitCode,
+				StartedAt: c.startedAt.Format(time.RFC3339Nano),
+			},
+			HostConfig: hostConfig,
+		},
+		Config: &container.Config{
+			Hostname:     c.name,
+			Image:        c.settings.Image(),
+			Env:          c.settings.EnvVars,
+			Labels:       c.settings.Labels,
+			ExposedPorts: exposedPorts,
+		},
+	}, nil
+}
+
+func (f *Fake) ListContainers(ctx context.Context, options ListOptions) ([]ContainerSummary, error) {
+	args, err := listFilters(options)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	summaries := []ContainerSummary{}
+	for _, c := range f.containers {
+		if !args.MatchKVList("label", c.settings.Labels) || !args.ExactMatch("status", c.state) {
+			continue
+		}
+
+		if options.Name != "" {
+			if matched, _ := path.Match(options.Name, c.name); !matched {
+				continue
+			}
+		}
+
+		summaries = append(summaries, ContainerSummary{
+			ID:      c.id,
+			Name:    c.name,
+			Image:   c.settings.Image(),
+			ImageID: c.imageID,
+			State:   c.state,
+			Status:  c.state,
+			Created: c.created,
+			Ports:   c.settings.Ports,
+			Labels:  c.settings.Labels,
+		})
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	return summaries, nil
+}
+
+func (f *Fake) Exec(ctx context.Context, containerName string, options ExecOptions, stdout io.Writer, stderr io.Writer) (int, error) {
+	f.mu.Lock()
+	c, err := f.find(containerName)
+	if err != nil {
+		f.mu.Unlock()
+		return 0, err
+	}
+
+	// The handler runs without the lock, so the container is copied while it's held
+	name, state, handler := c.name, c.state, f.execHandler
+	f.mu.Unlock()
+
+	if state != "running" {
+		return 0, errdefs.Conflict(fmt.Errorf("container %s is not running", name))
+	}
+
+	if handler == nil {
+		return 0, nil
+	}
+
+	return handler(name, options, stdout, stderr), nil
+}
+
+// Writes the logs of the container, following them waits for the context to be cancelled
+func (f *Fake) Logs(ctx context.Context, containerName string, options LogOptions, stdout io.Writer, stderr io.Writer) error {
+	f.mu.Lock()
+	c, err := f.find(containerName)
+	var outLogs, errLogs string
+	if err == nil {
+		outLogs, errLogs = c.stdout, c.stderr
+	}
+	f.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	showStdout, showStderr := options.Stdout, options.Stderr
+	if !showStdout && !showStderr {
+		showStdout, showStderr = true, true
+	}
+
+	if showStdout {
+		if _, err := io.WriteString(stdout, tailLines(outLogs, options.Tail)); err != nil {
+			return err
+		}
+	}
+
+	if showStderr {
+		if _, err := io.WriteString(stderr, tailLines(errLogs, options.Tail)); err != nil {
+			return err
+		}
+	}
+
+	if options.Follow {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+func (f *Fake) WatchContainers(ctx context.Context, options WatchOptions, handle func(ContainerEvent) error) error {
+	args, err := watchFilters(options)
+	if err != nil {
+		return err
+	}
+
+	watcher := &fakeWatcher{args: args, events: make(chan ContainerEvent, 100)}
+
+	f.mu.Lock()
+	f.watchers[watcher] = true
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		delete(f.watchers, watcher)
+		f.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event := <-watcher.events:
+			if err := handle(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Reports the stats set for the container, streaming them sends them once and waits for the context to be cancelled
+func (f *Fake) Stats(ctx context.Context, containerName string, stream bool, handle func(Stats) error) error {
+	f.mu.Lock()
+	c, err := f.find(containerName)
+	var stats Stats
+	if err == nil {
+		stats = c.stats
+		stats.ContainerID = c.id
+		stats.ContainerName = c.name
+		stats.Time = time.Now().UTC()
+	}
+	f.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if err := handle(stats); err != nil {
+		return err
+	}
+
+	if stream {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+func (f *Fake) ImageExists(ctx context.Context, image string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.findImage(image) != nil, nil
+}
+
+// Pulls the image reporting a download for a single layer
+func (f *Fake) PullImage(ctx context.Context, image string, handle func(PullProgress) error) error {
+	f.mu.Lock()
+	pulled := f.pull(image)
+	f.mu.Unlock()
+
+	layer := strings.TrimPrefix(pulled.id, "sha256:")[:12]
+	progress := []PullProgress{
+		{Layer: imageTag(image), Status: "Pulling from " + imageRepository(image)},
+		{Layer: layer, Status: "Downloading", Current: pulled.size / 2, Total: pulled.size},
+		{Layer: layer, Status: "Downloading", Current: pulled.size, Total: pulled.size},
+		{Layer: layer, Status: "Pull complete"},
+		{Status: "Digest: " + strings.TrimPrefix(pulled.id, "sha256:")},
+	}
+
+	for _, p := range progress {
+		if err := handle(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *Fake) ListImages(ctx context.Context) ([]ImageSummary, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	summaries := []ImageSummary{}
+	for _, image := range f.images {
+		summary := ImageSummary{
+			ID:      image.id,
+			Tags:    append([]string{}, image.tags...),
+			Digests: append([]string{}, image.digests...),
+			Created: image.created,
+			Size:    image.size,
+		}
+
+		for _, c := range f.containers {
+			if c.imageID == image.id {
+				summary.Containers++
+			}
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	sortImages(summaries)
+	return summaries, nil
+}
+
+func (f *Fake) RemoveImage(ctx context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.images[id]; !ok {
+		return errdefs.NotFound(fmt.Errorf("no such image: %s", id))
+	}
+
+	for _, c := range f.containers {
+		if c.imageID == id {
+			return errdefs.Conflict(fmt.Errorf("image %s is used by container %s", id, c.name))
+		}
+	}
+
+	delete(f.images, id)
+	return nil
+}
+
+func (f *Fake) NetworkExists(ctx context.Context, name string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.networks[name]
+	return ok, nil
+}
+
+func (f *Fake) CreateNetwork(ctx context.Context, name string, labels map[string]string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.networks[name]; ok {
+		return errdefs.Conflict(fmt.Errorf("network with name %s already exists", name))
+	}
+
+	f.networks[name] = labels
+	return nil
+}
+
+func (f *Fake) VolumeExists(ctx context.Context, name string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.volumes[name]
+	return ok, nil
+}
+
+func (f *Fake) CreateVolume(ctx context.Context, name string, labels map[string]string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.volumes[name]; !ok {
+		f.volumes[name] = labels
+	}
+
+	return nil
+}
+
+func (f *Fake) Info(ctx context.Context) (RuntimeInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.info, nil
+}
+
+func (f *Fake) Ping(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.pingErr
+}
+
+// Returns the image with the tag, images without a tag use the latest one. The lock must be held
+func (f *Fake) findImage(image string) *fakeImage {
+	tag := imageRepository(image) + ":" + imageTag(image)
+	for _, candidate := range f.images {
+		for _, t := range candidate.tags {
+			if t == tag {
+				return candidate
+			}
+		}
+	}
+
+	return nil
+}
+
+// Returns the image with the tag, adding it if it's missing. The lock must be held
+func (f *Fake) pull(image string) *fakeImage {
+	if existing := f.findImage(image); existing != nil {
+		return existing
+	}
+
+	f.created++
+	tag := imageRepository(image) + ":" + imageTag(image)
+	sum := sha256.Sum256([]byte(tag + strconv.Itoa(f.created)))
+	digest := hex.EncodeToString(sum[:])
+
+	pulled := &fakeImage{
+		id:      "sha256:" + digest,
+		tags:    []string{tag},
+		digests: []string{imageRepository(image) + "@sha256:" + digest},
+		// Images pulled in a row are apart so they are always sorted in the order they were pulled
+		created: time.Now().UTC().Add(time.Duration(f.created) * time.Second),
+		size:    100 << 20,
+	}
+	f.images[pulled.id] = pulled
+
+	return pulled
+}
+
+// Creates a running container, the lock must be held
+func (f *Fake) newContainer(settings Settings, image *fakeImage) *fakeContainer {
+	f.created++
+	sum := sha256.Sum256([]byte(settings.ContainerName + strconv.Itoa(f.created)))
+	now := time.Now().UTC()
+
+	c := &fakeContainer{
+		id:        hex.EncodeToString(sum[:]),
+		name:      settings.ContainerName,
+		imageID:   image.id,
+		settings:  settings,
+		state:     "running",
+		created:   now,
+		startedAt: now,
+	}
+	f.containers[c.id] = c
+
+	return c
+}
+
+// Finds a container by its name or ID, the lock must be held
+func (f *Fake) find(containerName string) (*fakeContainer, error) {
+	if c, ok := f.containers[containerName]; ok {
+		return c, nil
+	}
+
+	for _, c := range f.containers {
+		if c.name == strings.TrimPrefix(containerName, "/") {
+			return c, nil
+		}
+	}
+
+	return nil, errdefs.NotFound(fmt.Errorf("no such container: %s", containerName))
+}
+
+// Sends an event to the watchers whose filters match it, the lock must be held. Watchers that fall behind miss events
+func (f *Fake) emit(eventType EventType, c *fakeContainer) {
+	event := ContainerEvent{
+		Type:          eventType,
+		ContainerID:   c.id,
+		ContainerName: c.name,
+		Image:         c.settings.Image(),
+		Time:          time.Now().UTC(),
+	}
+	if eventType == EventDie {
+		event.ExitCode = c.exitCode
+	}
+
+	for watcher := range f.watchers {
+		args := watcher.args
+		if args.Contains("container") && !args.ExactMatch("container", c.name) && !args.ExactMatch("container", c.id) {
+			continue
+		}
+		if !args.MatchKVList("label", c.settings.Labels) || !args.ExactMatch("event", string(eventType)) {
+			continue
+		}
+
+		select {
+		case watcher.events <- event:
+		default:
+		}
+	}
+}
+
+// Returns the last lines of the logs, zero returns all of them
+func tailLines(logs string, tail int) string {
+	if tail <= 0 || logs == "" {
+		return logs
+	}
+
+	lines := strings.SplitAfter(logs, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) > tail {
+		lines = lines[len(lines)-tail:]
+	}
+
+	return strings.Join(lines, "")
+}
@@ -1,81 +1,219 @@
 package docker
 
 import (
-	"context"
-	"io"
-	"log"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
 
-	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
-	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 )
 
 // Settings used to create/delete a container
 type Settings struct {
 	ImageName     string
+	Tag           string
 	ContainerName string
 	EnvVars       []string
+	Ports         []PortMapping
+	Mounts        []Mount
+	RestartPolicy string
+	NetworkMode   string
+	Labels        map[string]string
+	ConfigDir     string
+	Devices       []Device
+	Privileged    bool
+	Readiness     *Readiness
 }
 
-// Creates a docker client
-func createClient() (*client.Client, error) {
-	return client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+// Port of the container published in the host, the protocol defaults to tcp
+type PortMapping struct {
+	HostIP        string
+	HostPort      int
+	ContainerPort int
+	Protocol      string
 }
 
-// Pulls an image from a remote registry
-func pullImage(client *client.Client, imageName string) error {
-	reader, err := client.ImagePull(context.Background(), imageName, types.ImagePullOptions{})
+// Volume or bind mount attached to the container. If the type is empty, sources that are absolute paths are bind
+// mounted and any other source is used as a volume name
+type Mount struct {
+	Type     string
+	Source   string
+	Target   string
+	ReadOnly bool
+}
+
+// Device of the host passed through to the container, such as a zigbee stick. The container path defaults to the
+// host path and the permissions to rwm
+type Device struct {
+	HostPath      string
+	ContainerPath string
+	Permissions   string
+}
 
+// Returns the image reference of the settings, the tag is only appended when it's set. Settings whose image already
+// has a tag or digest can't set the tag, ValidateSettings rejects them and the image is returned without the tag
+func (s *Settings) Image() string {
+	image, err := TaggedImage(s.ImageName, s.Tag)
 	if err != nil {
-		return err
+		return s.ImageName
 	}
 
-	defer reader.Close()
-	io.Copy(log.Default().Writer(), reader)
+	return image
+}
 
-	return nil
+// Converts the environment variables to KEY=value pairs, they are sorted so the same variables always create the same
+// container
+func EnvVars(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	vars := make([]string, 0, len(keys))
+	for _, key := range keys {
+		vars = append(vars, key+"="+env[key])
+	}
+
+	return vars
 }
 
-// Sets container's network settings. The port is hardcoded to 8123 since it's the one used by Home Assistant
-func setContainerSettings() (*container.HostConfig, *network.NetworkingConfig, map[nat.Port]struct{}, error) {
-	portNumber := "8123"
-	port, err := nat.NewPort("tcp", portNumber)
+// Sets container's network, port, mount, device and restart settings for the backend. When they are not specified,
+// the container is attached to the default network of the backend and restarted unless it's stopped. The home
+// assistant config directory is bind mounted in /config
+func setContainerSettings(settings *Settings, backend Backend) (*container.HostConfig, *network.NetworkingConfig, map[nat.Port]struct{}, error) {
+	networkMode := defaultNetwork(settings.NetworkMode, backend)
+	mode := container.NetworkMode(networkMode)
+
+	// Containers in the host network already listen on the ports of the host, so there is nothing to publish
+	mappings := settings.Ports
+	if mode.IsHost() {
+		mappings = nil
+	}
+
+	portBindings := nat.PortMap{}
+	exposedPorts := map[nat.Port]struct{}{}
+	for _, mapping := range mappings {
+		protocol := mapping.Protocol
+		if protocol == "" {
+			protocol = "tcp"
+		}
+
+		port, err := nat.NewPort(protocol, strconv.Itoa(mapping.ContainerPort))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		hostIP := mapping.HostIP
+		if hostIP == "" {
+			hostIP = "0.0.0.0"
+		}
+
+		hostPort := mapping.HostPort
+		if hostPort == 0 {
+			hostPort = mapping.ContainerPort
+		}
+
+		exposedPorts[port] = struct{}{}
+		portBindings[port] = append(portBindings[port], nat.PortBinding{
+			HostIP:   hostIP,
+			HostPort: strconv.Itoa(hostPort),
+		})
+	}
+
+	settingsMounts := append([]Mount{}, settings.Mounts...)
+	if settings.ConfigDir != "" {
+		settingsMounts = append(settingsMounts, Mount{Type: "bind", Source: settings.ConfigDir, Target: haConfigPath})
+	}
+
+	mounts, err := setMounts(settingsMounts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	restart, err := restartPolicy(settings.RestartPolicy, backend)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
 	hostConfig := &container.HostConfig{
-		PortBindings: nat.PortMap{
-			port: []nat.PortBinding{
-				{
-					HostIP:   "0.0.0.0",
-					HostPort: portNumber,
-				},
-			},
-		},
-		RestartPolicy: container.RestartPolicy{
-			Name: "unless-stopped",
-		},
+		PortBindings:  portBindings,
+		Mounts:        mounts,
+		NetworkMode:   mode,
+		Privileged:    settings.Privileged,
+		RestartPolicy: restart,
 		LogConfig: container.LogConfig{
 			Type:   "json-file",
 			Config: map[string]string{},
 		},
 	}
 
+	for _, device := range settings.Devices {
+		containerPath := device.ContainerPath
+		if containerPath == "" {
+			containerPath = device.HostPath
+		}
+
+		permissions := device.Permissions
+		if permissions == "" {
+			permissions = "rwm"
+		}
+
+		hostConfig.Devices = append(hostConfig.Devices, container.DeviceMapping{
+			PathOnHost:        device.HostPath,
+			PathInContainer:   containerPath,
+			CgroupPermissions: permissions,
+		})
+	}
+
 	networkConfig := &network.NetworkingConfig{
 		EndpointsConfig: map[string]*network.EndpointSettings{},
 	}
 
-	endpointConfig := &network.EndpointSettings{
-		Gateway: "gatewayname",
+	// Containers sharing the network stack of the host or other container can't be attached to a network
+	if !mode.IsHost() && !mode.IsNone() && !mode.IsContainer() {
+		endpointConfig := &network.EndpointSettings{
+			Gateway: "gatewayname",
+		}
+		networkConfig.EndpointsConfig[networkMode] = endpointConfig
 	}
-	networkConfig.EndpointsConfig["bridge"] = endpointConfig
 
-	exposedPorts := map[nat.Port]struct{}{
-		port: {},
+	return hostConfig, networkConfig, exposedPorts, nil
+}
+
+// Converts the mounts of the settings to the ones used by the docker API
+func setMounts(mounts []Mount) ([]mount.Mount, error) {
+	var dockerMounts []mount.Mount
+	for _, m := range mounts {
+		mountType := mount.Type(m.Type)
+		if mountType == "" {
+			mountType = mount.TypeVolume
+			if filepath.IsAbs(m.Source) {
+				mountType = mount.TypeBind
+			}
+		}
+
+		switch mountType {
+		case mount.TypeBind, mount.TypeVolume, mount.TypeTmpfs:
+		default:
+			return nil, fmt.Errorf("unsupported mount type %q", m.Type)
+		}
+
+		if m.Target == "" {
+			return nil, fmt.Errorf("mount of %q is missing its target", m.Source)
+		}
+
+		dockerMounts = append(dockerMounts, mount.Mount{
+			Type:     mountType,
+			Source:   m.Source,
+			Target:   m.Target,
+			ReadOnly: m.ReadOnly,
+		})
 	}
 
-	return hostConfig, networkConfig, exposedPorts, nil
+	return dockerMounts, nil
 }
@@ -0,0 +1,304 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+)
+
+// Images kept per repository when pruning if the options don't set it, the current one and one to roll back to
+const defaultKeepLast = 2
+
+// Returns the reference of the image with the tag, an image that already has a tag or digest can't get another tag
+func TaggedImage(image string, tag string) (string, error) {
+	if tag == "" {
+		return image, nil
+	}
+
+	if imageRepository(image) != image {
+		return "", errdefs.InvalidParameter(fmt.Errorf("the image %q already has a tag or digest, the tag %q can't be added", image, tag))
+	}
+
+	return image + ":" + tag, nil
+}
+
+// Progress of an image pull. Messages about a layer have its ID, the others are about the whole image, such as its
+// digest. Current and total are only set while downloading or extracting a layer
+type PullProgress struct {
+	Layer   string
+	Status  string
+	Current int64
+	Total   int64
+}
+
+// Summary of an image returned when listing them. Images whose tag was moved to a newer image have no tags but still
+// have the digests of their repositories
+type ImageSummary struct {
+	ID      string
+	Tags    []string
+	Digests []string
+	Created time.Time
+	Size    int64
+	// Number of containers using the image, -1 if the engine doesn't report it
+	Containers int64
+}
+
+// Images removed when pruning. Repositories limits the pruning to those repositories, every repository is pruned if
+// it's empty. With a dry run nothing is removed
+type PruneOptions struct {
+	KeepLast     int
+	Repositories []string
+	DryRun       bool
+}
+
+// Images removed, or that would be removed with a dry run, and the disk space they used
+type PruneResult struct {
+	Removed        []ImageSummary
+	SpaceReclaimed int64
+}
+
+// Message of the JSON stream returned while pulling an image
+type pullMessage struct {
+	ID       string `json:"id"`
+	Status   string `json:"status"`
+	Progress struct {
+		Current int64 `json:"current"`
+		Total   int64 `json:"total"`
+	} `json:"progressDetail"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"errorDetail"`
+}
+
+// Returns the repositories of the image from its tags and digests, such as homeassistant/home-assistant
+func (i ImageSummary) Repositories() []string {
+	seen := map[string]bool{}
+	var repositories []string
+	for _, ref := range append(append([]string{}, i.Tags...), i.Digests...) {
+		repository := imageRepository(ref)
+		if repository == "" || repository == "<none>" || seen[repository] {
+			continue
+		}
+
+		seen[repository] = true
+		repositories = append(repositories, repository)
+	}
+
+	return repositories
+}
+
+// Pulls an image from its registry calling the handler with the progress of every layer. Images without a tag pull
+// the latest one
+func (e *Engine) PullImage(ctx context.Context, image string, handle func(PullProgress) error) error {
+	client, err := e.createClient()
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	return pullImage(ctx, client, image, handle)
+}
+
+// Lists every image in the machine, the newest ones first
+func (e *Engine) ListImages(ctx context.Context) ([]ImageSummary, error) {
+	client, err := e.createClient()
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	images, err := client.ImageList(ctx, types.ImageListOptions{ContainerCount: true})
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := []ImageSummary{}
+	for _, image := range images {
+		summary := ImageSummary{
+			ID:         image.ID,
+			Digests:    image.RepoDigests,
+			Created:    time.Unix(image.Created, 0).UTC(),
+			Size:       image.Size,
+			Containers: image.Containers,
+		}
+
+		for _, tag := range image.RepoTags {
+			if tag != "<none>:<none>" {
+				summary.Tags = append(summary.Tags, tag)
+			}
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	sortImages(summaries)
+	return summaries, nil
+}
+
+// Removes an image along with all its tags, it fails if a container is using it
+func (e *Engine) RemoveImage(ctx context.Context, id string) error {
+	client, err := e.createClient()
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	containers, err := client.ContainerList(ctx, types.ContainerListOptions{All: true})
+	if err != nil {
+		return err
+	}
+
+	for _, container := range containers {
+		if container.ImageID == id {
+			return errdefs.Conflict(fmt.Errorf("image %s is used by container %s", id, containerName(container.Names)))
+		}
+	}
+
+	// Forcing it is needed to remove images with several tags, the containers were already checked
+	_, err = client.ImageRemove(ctx, id, types.ImageRemoveOptions{Force: true, PruneChildren: true})
+	return err
+}
+
+// This function removes the old images of every repository keeping the newest ones, so the previous versions are
+// still there to roll back to. Images used by a container are never removed, and neither are the ones that also belong
+// to a repository that is not being pruned
+func PruneImages(ctx context.Context, rt Runtime, options PruneOptions) (PruneResult, error) {
+	keepLast := options.KeepLast
+	if keepLast < 0 {
+		return PruneResult{}, errdefs.InvalidParameter(fmt.Errorf("the number of images to keep can't be negative"))
+	}
+	if keepLast == 0 {
+		keepLast = defaultKeepLast
+	}
+
+	pruned := map[string]bool{}
+	for _, repository := range options.Repositories {
+		pruned[repository] = true
+	}
+
+	images, err := rt.ListImages(ctx)
+	if err != nil {
+		return PruneResult{}, err
+	}
+
+	containers, err := rt.ListContainers(ctx, ListOptions{})
+	if err != nil {
+		return PruneResult{}, err
+	}
+
+	inUse := map[string]bool{}
+	for _, container := range containers {
+		inUse[container.ImageID] = true
+	}
+
+	// Images are sorted from newest to oldest, so the first ones found in a repository are kept
+	kept := map[string]int{}
+	result := PruneResult{Removed: []ImageSummary{}}
+	for _, image := range images {
+		repositories := image.Repositories()
+		if len(repositories) == 0 {
+			continue
+		}
+
+		remove := true
+		for _, repository := range repositories {
+			if len(pruned) > 0 && !pruned[repository] {
+				remove = false
+			}
+
+			if kept[repository] < keepLast {
+				kept[repository]++
+				remove = false
+			}
+		}
+
+		if inUse[image.ID] || image.Containers > 0 || !remove {
+			continue
+		}
+
+		if !options.DryRun {
+			if err := rt.RemoveImage(ctx, image.ID); err != nil {
+				return result, err
+			}
+			log.Printf("Removed image %s (%s)", strings.Join(repositories, ", "), image.ID)
+		}
+
+		result.Removed = append(result.Removed, image)
+		result.SpaceReclaimed += image.Size
+	}
+
+	return result, nil
+}
+
+// Pulls an image decoding the JSON stream returned by docker, the stream reports errors like missing images after the
+// request succeeds
+func pullImage(ctx context.Context, client *client.Client, image string, handle func(PullProgress) error) error {
+	reader, err := client.ImagePull(ctx, image, types.ImagePullOptions{})
+	if err != nil {
+		return err
+	}
+
+	defer reader.Close()
+
+	decoder := json.NewDecoder(reader)
+	for {
+		var message pullMessage
+		if err := decoder.Decode(&message); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+
+		if message.Error != nil {
+			return fmt.Errorf("unable to pull %s: %s", image, message.Error.Message)
+		}
+
+		err := handle(PullProgress{
+			Layer:   message.ID,
+			Status:  message.Status,
+			Current: message.Progress.Current,
+			Total:   message.Progress.Total,
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Logs the progress of a pull without the download and extraction updates, it's used when pulling images to start
+// containers
+func logPullProgress(image string) func(PullProgress) error {
+	return func(progress PullProgress) error {
+		if progress.Total == 0 {
+			if progress.Layer != "" {
+				log.Printf("%s: %s %s", image, progress.Layer, progress.Status)
+			} else {
+				log.Printf("%s: %s", image, progress.Status)
+			}
+		}
+
+		return nil
+	}
+}
+
+// Sorts the images from newest to oldest
+func sortImages(images []ImageSummary) {
+	sort.SliceStable(images, func(i, j int) bool {
+		return images[i].Created.After(images[j].Created)
+	})
+}
@@ -0,0 +1,158 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// Returned when the filters used to list containers are malformed
+var ErrInvalidFilter = errors.New("invalid container filter")
+
+// States a container can be in, as reported by docker
+var containerStates = map[string]bool{
+	"created":    true,
+	"restarting": true,
+	"running":    true,
+	"removing":   true,
+	"paused":     true,
+	"exited":     true,
+	"dead":       true,
+}
+
+// Filters used to list containers, empty filters match every container. Labels are either a key, which matches any
+// container that has the label, or key=value. The name is a glob pattern such as ha-* and a container matches if it's
+// in any of the states
+type ListOptions struct {
+	Labels []string
+	Name   string
+	States []string
+}
+
+// Summary of a container returned when listing them
+type ContainerSummary struct {
+	ID      string
+	Name    string
+	Image   string
+	ImageID string
+	State   string
+	Status  string
+	Created time.Time
+	Ports   []PortMapping
+	Labels  map[string]string
+}
+
+// This function lists every container in the machine, running or not, that matches the filters. The containers are
+// sorted by name
+func (e *Engine) ListContainers(ctx context.Context, options ListOptions) ([]ContainerSummary, error) {
+	args, err := listFilters(options)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := e.createClient()
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	containers, err := client.ContainerList(ctx, types.ContainerListOptions{
+		All:     true,
+		Filters: args,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := []ContainerSummary{}
+	for _, container := range containers {
+		name := containerName(container.Names)
+
+		// Docker only filters names by substring, so the glob is matched here
+		if options.Name != "" {
+			if matched, _ := path.Match(options.Name, name); !matched {
+				continue
+			}
+		}
+
+		summary := ContainerSummary{
+			ID:      container.ID,
+			Name:    name,
+			Image:   container.Image,
+			ImageID: container.ImageID,
+			State:   container.State,
+			Status:  container.Status,
+			Created: time.Unix(container.Created, 0).UTC(),
+			Labels:  container.Labels,
+		}
+
+		for _, port := range container.Ports {
+			summary.Ports = append(summary.Ports, PortMapping{
+				HostIP:        port.IP,
+				HostPort:      int(port.PublicPort),
+				ContainerPort: int(port.PrivatePort),
+				Protocol:      port.Type,
+			})
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	return summaries, nil
+}
+
+// Validates the options and converts the label and state filters to the ones used by the docker API
+func listFilters(options ListOptions) (filters.Args, error) {
+	args := filters.NewArgs()
+
+	for _, label := range options.Labels {
+		if label == "" || strings.HasPrefix(label, "=") {
+			return args, fmt.Errorf("%w: label filter %q is missing its key", ErrInvalidFilter, label)
+		}
+		args.Add("label", label)
+	}
+
+	if options.Name != "" {
+		if _, err := path.Match(options.Name, ""); err != nil {
+			return args, fmt.Errorf("%w: name pattern %q: %v", ErrInvalidFilter, options.Name, err)
+		}
+	}
+
+	for _, state := range options.States {
+		if !containerStates[state] {
+			return args, fmt.Errorf("%w: unknown state %q", ErrInvalidFilter, state)
+		}
+		args.Add("status", state)
+	}
+
+	return args, nil
+}
+
+// Returns the name of a container without the leading slash docker adds to it. Containers linked to others have more
+// names, the one without slashes inside is the actual name
+func containerName(names []string) string {
+	for _, name := range names {
+		name = strings.TrimPrefix(name, "/")
+		if !strings.Contains(name, "/") {
+			return name
+		}
+	}
+
+	if len(names) > 0 {
+		return strings.TrimPrefix(names[0], "/")
+	}
+
+	return ""
+}
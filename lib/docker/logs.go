@@ -0,0 +1,73 @@
+package docker
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// Logs read from a container. Since and until take the same values as docker logs, such as RFC3339 timestamps or
+// durations like 10m. A tail of zero returns every line and when neither stdout nor stderr are selected both are
+// returned
+type LogOptions struct {
+	Follow     bool
+	Since      string
+	Until      string
+	Tail       int
+	Timestamps bool
+	Stdout     bool
+	Stderr     bool
+}
+
+// Copies the logs of a container to the writers, stdout and stderr are split unless the container uses a TTY, in which
+// case everything is written to stdout. When following the logs, it only returns once the context is cancelled or
+// the container stops
+func (e *Engine) Logs(ctx context.Context, containerName string, options LogOptions, stdout io.Writer, stderr io.Writer) error {
+	client, err := e.createClient()
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	container, err := client.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return err
+	}
+
+	showStdout, showStderr := options.Stdout, options.Stderr
+	if !showStdout && !showStderr {
+		showStdout, showStderr = true, true
+	}
+
+	tail := "all"
+	if options.Tail > 0 {
+		tail = strconv.Itoa(options.Tail)
+	}
+
+	reader, err := client.ContainerLogs(ctx, container.ID, types.ContainerLogsOptions{
+		ShowStdout: showStdout,
+		ShowStderr: showStderr,
+		Since:      options.Since,
+		Until:      options.Until,
+		Timestamps: options.Timestamps,
+		Follow:     options.Follow,
+		Tail:       tail,
+	})
+	if err != nil {
+		return err
+	}
+
+	defer reader.Close()
+
+	if container.Config != nil && container.Config.Tty {
+		_, err = io.Copy(stdout, reader)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, reader)
+	}
+
+	return err
+}
@@ -0,0 +1,110 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/errdefs"
+)
+
+// Restarts the container, it's only used by the plans of single containers
+const StackRestart StackAction = "restart"
+
+// What an operation would do to a container without doing it. The image is the one that would be pulled, images that
+// are already present are pulled again to get the latest version of their tag
+type ContainerPlan struct {
+	ContainerName string
+	Image         string
+	ImagePresent  bool
+	CurrentImage  string
+	Action        StackAction
+	Reason        string
+}
+
+// This function returns what starting a container with the settings would do. It fails the same way starting it
+// would if a container with the same name already exists
+func PlanStartContainer(ctx context.Context, rt Runtime, settings *Settings) (ContainerPlan, error) {
+	info, err := rt.Info(ctx)
+	if err != nil {
+		return ContainerPlan{}, err
+	}
+
+	if _, _, _, err := setContainerSettings(settings, info.Backend); err != nil {
+		return ContainerPlan{}, err
+	}
+
+	current, err := rt.GetContainer(ctx, settings.ContainerName)
+	if err == nil {
+		return ContainerPlan{}, errdefs.Conflict(fmt.Errorf("container %s already exists (%s)", settings.ContainerName, current.ID))
+	}
+	if !errdefs.IsNotFound(err) {
+		return ContainerPlan{}, err
+	}
+
+	present, err := rt.ImageExists(ctx, settings.Image())
+	if err != nil {
+		return ContainerPlan{}, err
+	}
+
+	return ContainerPlan{
+		ContainerName: settings.ContainerName,
+		Image:         settings.Image(),
+		ImagePresent:  present,
+		Action:        StackCreate,
+		Reason:        "container doesn't exist",
+	}, nil
+}
+
+// This function returns what upgrading a container would do, the container is recreated even if the image stays the
+// same since the tag might point to a newer version once it's pulled
+func PlanUpgradeContainer(ctx context.Context, rt Runtime, containerName string, options UpgradeOptions) (ContainerPlan, error) {
+	current, err := rt.GetContainer(ctx, containerName)
+	if err != nil {
+		return ContainerPlan{}, err
+	}
+
+	image, err := upgradeImage(current.Config.Image, options)
+	if err != nil {
+		return ContainerPlan{}, err
+	}
+
+	present, err := rt.ImageExists(ctx, image)
+	if err != nil {
+		return ContainerPlan{}, err
+	}
+
+	reason := fmt.Sprintf("image changes from %s to %s", current.Config.Image, image)
+	if image == current.Config.Image {
+		reason = fmt.Sprintf("image %s is pulled again", image)
+	}
+
+	return ContainerPlan{
+		ContainerName: strings.TrimPrefix(current.Name, "/"),
+		Image:         image,
+		ImagePresent:  present,
+		CurrentImage:  current.Config.Image,
+		Action:        StackRecreate,
+		Reason:        reason,
+	}, nil
+}
+
+// This function returns what stopping or restarting a container would do, the container must exist
+func PlanContainerAction(ctx context.Context, rt Runtime, containerName string, action StackAction) (ContainerPlan, error) {
+	current, err := rt.GetContainer(ctx, containerName)
+	if err != nil {
+		return ContainerPlan{}, err
+	}
+
+	reason := "container is restarted"
+	if action == StackRemove {
+		reason = "container is stopped and removed along with its anonymous volumes"
+	}
+
+	return ContainerPlan{
+		ContainerName: strings.TrimPrefix(current.Name, "/"),
+		CurrentImage:  current.Config.Image,
+		Action:        action,
+		Reason:        reason,
+	}, nil
+}
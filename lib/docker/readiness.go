@@ -0,0 +1,254 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+const (
+	// Time a container has to become ready unless another timeout is specified
+	defaultReadyTimeout = 2 * time.Minute
+	// Time between every check of the state of the container
+	readinessInterval = time.Second
+	// Time a container without a healthcheck must stay running to be considered ready
+	stablePeriod = 5 * time.Second
+	// Time every HTTP probe has to get an answer
+	probeTimeout = 2 * time.Second
+	// Number of log lines returned when a container doesn't become ready
+	notReadyLogLines = 20
+	// Port where the home assistant web server and API listen
+	haPort = 8123
+)
+
+// Returned when a container doesn't become ready
+var ErrNotReady = errors.New("container is not ready")
+
+// Way to decide when a container is ready
+type ReadinessMode string
+
+const (
+	// Uses the HEALTHCHECK of the image if it has one, otherwise the container must keep running for a few seconds
+	ReadinessAuto ReadinessMode = ""
+	// Waits for docker to report the container as healthy, the image or the container must define a HEALTHCHECK
+	ReadinessHealthcheck ReadinessMode = "healthcheck"
+	// Waits for the container to answer HTTP requests, by default on the home assistant API
+	ReadinessHTTP ReadinessMode = "http"
+)
+
+// Settings used to wait for a container to become ready after it starts. The URL is only used by the HTTP mode and
+// defaults to the home assistant API on the host port where 8123 is published. Any answer below 500 counts as ready,
+// so the 401 of the API without a token means home assistant is listening
+type Readiness struct {
+	Mode    ReadinessMode
+	URL     string
+	Timeout time.Duration
+}
+
+// Error returned when a container doesn't become ready, it keeps the last lines of its logs to tell why
+type NotReadyError struct {
+	ContainerName string
+	Reason        string
+	TimedOut      bool
+	Logs          []string
+}
+
+func (e *NotReadyError) Error() string {
+	message := fmt.Sprintf("%v: %s %s", ErrNotReady, e.ContainerName, e.Reason)
+	if len(e.Logs) > 0 {
+		message += "\nlast log lines:\n" + strings.Join(e.Logs, "\n")
+	}
+
+	return message
+}
+
+func (e *NotReadyError) Unwrap() error {
+	return ErrNotReady
+}
+
+// This function waits until the container is ready or the timeout expires. Containers that exit, restart or become
+// unhealthy fail right away. When the container is not ready, the returned NotReadyError has its last log lines
+func waitReady(ctx context.Context, client *client.Client, id string, readiness Readiness) error {
+	timeout := readiness.Timeout
+	if timeout <= 0 {
+		timeout = defaultReadyTimeout
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(readinessInterval)
+	defer ticker.Stop()
+
+	for {
+		container, err := client.ContainerInspect(waitCtx, id)
+		if err != nil && waitCtx.Err() == nil {
+			return err
+		}
+
+		if err == nil {
+			ready, reason := checkReady(waitCtx, container, readiness)
+			if ready {
+				return nil
+			}
+			if reason != "" {
+				return notReady(client, container, reason, false)
+			}
+		}
+
+		select {
+		case <-waitCtx.Done():
+			if container.ContainerJSONBase == nil {
+				container, err = client.ContainerInspect(context.Background(), id)
+				if err != nil {
+					return err
+				}
+			}
+
+			// The request being cancelled is not the container's fault
+			if ctx.Err() != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return ctx.Err()
+			}
+
+			return notReady(client, container, fmt.Sprintf("is not ready after %s", timeout), true)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Checks the state of the container. It returns if the container is ready and, when it will never become ready, the
+// reason why
+func checkReady(ctx context.Context, container types.ContainerJSON, readiness Readiness) (bool, string) {
+	state := container.State
+	switch {
+	case state.Status == "exited" || state.Status == "dead":
+		return false, fmt.Sprintf("exited with code %d", state.ExitCode)
+	case state.Restarting:
+		return false, fmt.Sprintf("is restarting after exiting with code %d", state.ExitCode)
+	case !state.Running:
+		return false, ""
+	case state.Health != nil && state.Health.Status == "unhealthy":
+		return false, fmt.Sprintf("is unhealthy after %d failed checks", state.Health.FailingStreak)
+	}
+
+	switch readiness.Mode {
+	case ReadinessHealthcheck:
+		if state.Health == nil {
+			return false, "has no healthcheck"
+		}
+		return state.Health.Status == "healthy", ""
+	case ReadinessHTTP:
+		return probe(ctx, readiness.URL), ""
+	default:
+		if state.Health != nil {
+			return state.Health.Status == "healthy", ""
+		}
+
+		startedAt, err := time.Parse(time.RFC3339Nano, state.StartedAt)
+		return err == nil && time.Since(startedAt) >= stablePeriod, ""
+	}
+}
+
+// Sends a request to the URL, the server is ready if it answers without a server error
+func probe(ctx context.Context, url string) bool {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return false
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, response.Body)
+
+	return response.StatusCode < http.StatusInternalServerError
+}
+
+// Builds the error of a container that isn't ready with its last log lines
+func notReady(client *client.Client, container types.ContainerJSON, reason string, timedOut bool) error {
+	return &NotReadyError{
+		ContainerName: strings.TrimPrefix(container.Name, "/"),
+		Reason:        reason,
+		TimedOut:      timedOut,
+		Logs:          lastLogs(client, container, notReadyLogLines),
+	}
+}
+
+// Returns the last lines of the logs of the container, stdout and stderr are merged. Errors are ignored since the logs
+// only add context to another error
+func lastLogs(client *client.Client, container types.ContainerJSON, lines int) []string {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+
+	reader, err := client.ContainerLogs(ctx, container.ID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       strconv.Itoa(lines),
+	})
+	if err != nil {
+		return nil
+	}
+	defer reader.Close()
+
+	var output bytes.Buffer
+	if container.Config != nil && container.Config.Tty {
+		io.Copy(&output, reader)
+	} else {
+		stdcopy.StdCopy(&output, &output, reader)
+	}
+
+	text := strings.TrimRight(output.String(), "\n")
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(text, "\n")
+}
+
+// Returns the URL probed in the HTTP mode, by default the home assistant API on the host port where it's published.
+// Containers in the host network listen directly on the port of the host
+func readinessURL(settings *Settings) (string, error) {
+	if settings.Readiness.URL != "" {
+		return settings.Readiness.URL, nil
+	}
+
+	if settings.NetworkMode == "host" {
+		return fmt.Sprintf("http://127.0.0.1:%d/api/", haPort), nil
+	}
+
+	for _, port := range settings.Ports {
+		if port.ContainerPort != haPort || (port.Protocol != "" && port.Protocol != "tcp") {
+			continue
+		}
+
+		hostIP := port.HostIP
+		if hostIP == "" || hostIP == "0.0.0.0" {
+			hostIP = "127.0.0.1"
+		}
+
+		hostPort := port.HostPort
+		if hostPort == 0 {
+			hostPort = port.ContainerPort
+		}
+
+		return fmt.Sprintf("http://%s/api/", net.JoinHostPort(hostIP, strconv.Itoa(hostPort))), nil
+	}
+
+	return "", fmt.Errorf("%w: the readiness URL is required when port %d is not published", ErrInvalidSettings, haPort)
+}
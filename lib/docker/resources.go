@@ -0,0 +1,95 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+)
+
+// Checks if the image is already in the machine
+func (e *Engine) ImageExists(ctx context.Context, image string) (bool, error) {
+	client, err := e.createClient()
+	if err != nil {
+		return false, err
+	}
+
+	defer client.Close()
+
+	_, _, err = client.ImageInspectWithRaw(ctx, image)
+	if errdefs.IsNotFound(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Checks if a network with the name exists
+func (e *Engine) NetworkExists(ctx context.Context, name string) (bool, error) {
+	client, err := e.createClient()
+	if err != nil {
+		return false, err
+	}
+
+	defer client.Close()
+
+	_, err = client.NetworkInspect(ctx, name, types.NetworkInspectOptions{})
+	if errdefs.IsNotFound(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Creates a bridge network with the labels
+func (e *Engine) CreateNetwork(ctx context.Context, name string, labels map[string]string) error {
+	client, err := e.createClient()
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	_, err = client.NetworkCreate(ctx, name, types.NetworkCreate{
+		CheckDuplicate: true,
+		Driver:         "bridge",
+		Labels:         labels,
+	})
+
+	return err
+}
+
+// Checks if a volume with the name exists
+func (e *Engine) VolumeExists(ctx context.Context, name string) (bool, error) {
+	client, err := e.createClient()
+	if err != nil {
+		return false, err
+	}
+
+	defer client.Close()
+
+	_, err = client.VolumeInspect(ctx, name)
+	if errdefs.IsNotFound(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Creates a local volume with the labels
+func (e *Engine) CreateVolume(ctx context.Context, name string, labels map[string]string) error {
+	client, err := e.createClient()
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	_, err = client.VolumeCreate(ctx, volume.CreateOptions{
+		Name:   name,
+		Labels: labels,
+	})
+
+	return err
+}
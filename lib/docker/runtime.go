@@ -0,0 +1,76 @@
+package docker
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+)
+
+// Container operations used by the servers, the Engine runs them against a docker daemon and the Fake keeps the
+// containers in memory. Any other runtime with a docker compatible API can be used through the Engine. Containers are
+// referenced by name or ID and errors follow the errdefs classes of docker, such as errdefs.NotFound
+type Runtime interface {
+	// Pulls the image, creates the container and starts it, waiting for it to be ready if the settings ask for it
+	StartContainer(ctx context.Context, settings *Settings) (string, error)
+	// Stops the container and removes it along with its anonymous volumes
+	StopContainer(ctx context.Context, containerName string) error
+	// Removes the container even if it's running, its volumes are kept
+	RemoveContainer(ctx context.Context, containerName string) error
+	RestartContainer(ctx context.Context, containerName string) error
+	// Recreates the container with a new image and returns the ID of the new container
+	UpgradeContainer(ctx context.Context, containerName string, options UpgradeOptions) (string, error)
+	GetContainer(ctx context.Context, containerName string) (types.ContainerJSON, error)
+	ListContainers(ctx context.Context, options ListOptions) ([]ContainerSummary, error)
+	// Runs a command inside the container and returns its exit code
+	Exec(ctx context.Context, containerName string, options ExecOptions, stdout io.Writer, stderr io.Writer) (int, error)
+	Logs(ctx context.Context, containerName string, options LogOptions, stdout io.Writer, stderr io.Writer) error
+	WatchContainers(ctx context.Context, options WatchOptions, handle func(ContainerEvent) error) error
+	// Calls the handler with the resource usage of the container, once or every time it's sampled
+	Stats(ctx context.Context, containerName string, stream bool, handle func(Stats) error) error
+	ImageExists(ctx context.Context, image string) (bool, error)
+	NetworkExists(ctx context.Context, name string) (bool, error)
+	CreateNetwork(ctx context.Context, name string, labels map[string]string) error
+	VolumeExists(ctx context.Context, name string) (bool, error)
+	CreateVolume(ctx context.Context, name string, labels map[string]string) error
+	// Pulls the image calling the handler with the progress of its layers
+	PullImage(ctx context.Context, image string, handle func(PullProgress) error) error
+	ListImages(ctx context.Context) ([]ImageSummary, error)
+	// Removes the image by ID along with all its tags, it fails if a container is using it
+	RemoveImage(ctx context.Context, id string) error
+	// Returns the container engine used by the runtime
+	Info(ctx context.Context) (RuntimeInfo, error)
+	// Checks that the container engine can be reached
+	Ping(ctx context.Context) error
+}
+
+// Runtime that talks to a docker daemon, a new client is created for every operation
+type Engine struct {
+	opts []client.Opt
+	mu   sync.Mutex
+	info *RuntimeInfo
+}
+
+// Returns an engine configured from the environment like the docker CLI, such as DOCKER_HOST. When it's not set, the
+// docker or podman socket found in the machine is used. The options are applied on top of it
+func NewEngine(opts ...client.Opt) *Engine {
+	return &Engine{opts: opts}
+}
+
+// Creates a docker client
+func (e *Engine) createClient() (*client.Client, error) {
+	opts := []client.Opt{client.FromEnv, client.WithAPIVersionNegotiation()}
+	if os.Getenv(client.EnvOverrideHost) == "" {
+		if host := DetectSocket(); host != "" {
+			opts = append(opts, client.WithHost(host))
+		}
+	}
+
+	opts = append(opts, e.opts...)
+	return client.NewClientWithOpts(opts...)
+}
+
+var _ Runtime = (*Engine)(nil)
@@ -0,0 +1,345 @@
+package docker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+
+	"github.com/docker/docker/errdefs"
+)
+
+// Labels used to find the resources managed by a stack
+const (
+	StackLabel      = "ha-utils.stack"
+	ServiceLabel    = "ha-utils.service"
+	ConfigHashLabel = "ha-utils.config-hash"
+)
+
+var (
+	// Returned when the stack spec is malformed
+	ErrInvalidStack = errors.New("invalid stack")
+	// Returned when a resource of the stack already exists and it's not managed by the stack
+	ErrStackConflict = errors.New("stack conflict")
+)
+
+// Names of stacks and services, they are used as part of the container names
+var stackNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Desired state of a group of containers, such as home assistant with its MQTT broker. Services are created in the
+// order they are declared, networks and volumes are created before any service that might use them
+type Stack struct {
+	Name     string
+	Networks []string
+	Volumes  []string
+	Services []Service
+}
+
+// Container of a stack. The container name defaults to <stack>-<service>
+type Service struct {
+	Name     string
+	Settings *Settings
+}
+
+// What applying a stack does to one of its resources
+type StackAction string
+
+const (
+	StackUnchanged StackAction = "unchanged"
+	StackCreate    StackAction = "create"
+	StackRecreate  StackAction = "recreate"
+	StackRemove    StackAction = "remove"
+)
+
+// Kind of resource managed by a stack
+type ResourceKind string
+
+const (
+	ResourceNetwork ResourceKind = "network"
+	ResourceVolume  ResourceKind = "volume"
+	ResourceService ResourceKind = "service"
+)
+
+// Change needed to converge a resource of the stack, the reason tells why it changes
+type PlanStep struct {
+	Kind   ResourceKind
+	Name   string
+	Action StackAction
+	Reason string
+}
+
+// Changes needed to converge a stack in the order they are applied
+type Plan struct {
+	Stack string
+	Steps []PlanStep
+}
+
+// Returns if any of the steps changes something
+func (p Plan) HasChanges() bool {
+	for _, step := range p.Steps {
+		if step.Action != StackUnchanged {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Outcome of applying a step, the container ID is set for services that were created or recreated
+type StepResult struct {
+	Step        PlanStep
+	ContainerID string
+	Err         error
+}
+
+// This function validates the stack and the settings of its services, bind mounts must be inside the allowed root
+// like in any other container. Services without a container name get <stack>-<service>
+func ValidateStack(stack *Stack, allowedRoot string) error {
+	if !stackNamePattern.MatchString(stack.Name) {
+		return fmt.Errorf("%w: invalid stack name %q", ErrInvalidStack, stack.Name)
+	}
+
+	if len(stack.Services) == 0 {
+		return fmt.Errorf("%w: %s has no services", ErrInvalidStack, stack.Name)
+	}
+
+	services := map[string]bool{}
+	containers := map[string]bool{}
+	for i := range stack.Services {
+		service := &stack.Services[i]
+		if !stackNamePattern.MatchString(service.Name) {
+			return fmt.Errorf("%w: invalid service name %q", ErrInvalidStack, service.Name)
+		}
+		if services[service.Name] {
+			return fmt.Errorf("%w: service %s is declared twice", ErrInvalidStack, service.Name)
+		}
+		services[service.Name] = true
+
+		if service.Settings == nil || service.Settings.ImageName == "" {
+			return fmt.Errorf("%w: service %s is missing its image", ErrInvalidStack, service.Name)
+		}
+
+		if service.Settings.ContainerName == "" {
+			service.Settings.ContainerName = stack.Name + "-" + service.Name
+		}
+		if containers[service.Settings.ContainerName] {
+			return fmt.Errorf("%w: container %s is used by two services", ErrInvalidStack, service.Settings.ContainerName)
+		}
+		containers[service.Settings.ContainerName] = true
+
+		if err := ValidateSettings(service.Settings, allowedRoot); err != nil {
+			return fmt.Errorf("service %s: %w", service.Name, err)
+		}
+	}
+
+	for _, name := range append(append([]string{}, stack.Networks...), stack.Volumes...) {
+		if !stackNamePattern.MatchString(name) {
+			return fmt.Errorf("%w: invalid network or volume name %q", ErrInvalidStack, name)
+		}
+	}
+
+	return nil
+}
+
+// This function compares the stack with the resources that exist in docker and returns the steps to converge them.
+// Services are recreated when their settings changed since they were created, which is tracked with a hash of the
+// settings in the container labels, or when their container is not running. Containers of the stack whose service was
+// removed from the spec are removed too. Networks and volumes are only created, never removed, so no data is lost
+func PlanStack(ctx context.Context, rt Runtime, stack *Stack) (Plan, error) {
+	plan := Plan{Stack: stack.Name}
+
+	for _, name := range stack.Networks {
+		step := PlanStep{Kind: ResourceNetwork, Name: name, Action: StackUnchanged, Reason: "exists"}
+		exists, err := rt.NetworkExists(ctx, name)
+		if err != nil {
+			return Plan{}, err
+		}
+		if !exists {
+			step.Action, step.Reason = StackCreate, "network doesn't exist"
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	for _, name := range stack.Volumes {
+		step := PlanStep{Kind: ResourceVolume, Name: name, Action: StackUnchanged, Reason: "exists"}
+		exists, err := rt.VolumeExists(ctx, name)
+		if err != nil {
+			return Plan{}, err
+		}
+		if !exists {
+			step.Action, step.Reason = StackCreate, "volume doesn't exist"
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	managed, err := rt.ListContainers(ctx, ListOptions{Labels: []string{StackLabel + "=" + stack.Name}})
+	if err != nil {
+		return Plan{}, err
+	}
+
+	byService := map[string]ContainerSummary{}
+	for _, container := range managed {
+		byService[container.Labels[ServiceLabel]] = container
+	}
+
+	for _, service := range stack.Services {
+		step := PlanStep{Kind: ResourceService, Name: service.Name}
+		hash, err := configHash(service.Settings)
+		if err != nil {
+			return Plan{}, err
+		}
+
+		container, exists := byService[service.Name]
+		switch {
+		case !exists:
+			if err := checkUnmanaged(ctx, rt, service.Settings.ContainerName); err != nil {
+				return Plan{}, err
+			}
+			step.Action, step.Reason = StackCreate, "container doesn't exist"
+		case container.Name != service.Settings.ContainerName:
+			step.Action, step.Reason = StackRecreate, "container name changed"
+		case container.Labels[ConfigHashLabel] != hash:
+			step.Action, step.Reason = StackRecreate, "settings changed"
+		case container.State != "running":
+			step.Action, step.Reason = StackRecreate, "container is "+container.State
+		default:
+			step.Action, step.Reason = StackUnchanged, "up to date"
+		}
+
+		plan.Steps = append(plan.Steps, step)
+		delete(byService, service.Name)
+	}
+
+	// Containers left belong to services that are no longer in the spec
+	orphans := make([]string, 0, len(byService))
+	for service := range byService {
+		orphans = append(orphans, service)
+	}
+	sort.Strings(orphans)
+
+	for _, service := range orphans {
+		plan.Steps = append(plan.Steps, PlanStep{
+			Kind:   ResourceService,
+			Name:   service,
+			Action: StackRemove,
+			Reason: "service is no longer in the stack",
+		})
+	}
+
+	return plan, nil
+}
+
+// This function applies the steps of the plan in order and reports the result of each one as it finishes. It stops at
+// the first step that fails, since later services might depend on it
+func ApplyStack(ctx context.Context, rt Runtime, stack *Stack, plan Plan, report func(StepResult) error) error {
+	services := map[string]Service{}
+	for _, service := range stack.Services {
+		services[service.Name] = service
+	}
+
+	labels := map[string]string{StackLabel: stack.Name}
+	for _, step := range plan.Steps {
+		result := StepResult{Step: step}
+
+		switch {
+		case step.Action == StackUnchanged:
+		case step.Kind == ResourceNetwork:
+			result.Err = rt.CreateNetwork(ctx, step.Name, labels)
+		case step.Kind == ResourceVolume:
+			result.Err = rt.CreateVolume(ctx, step.Name, labels)
+		case step.Action == StackRemove:
+			result.Err = removeService(ctx, rt, stack.Name, step.Name)
+		default:
+			result.ContainerID, result.Err = applyService(ctx, rt, stack.Name, services[step.Name], step.Action)
+		}
+
+		if result.Err != nil {
+			log.Printf("Unable to %s %s %s of stack %s: %v", step.Action, step.Kind, step.Name, stack.Name, result.Err)
+		}
+
+		if err := report(result); err != nil {
+			return err
+		}
+
+		if result.Err != nil {
+			return result.Err
+		}
+	}
+
+	return nil
+}
+
+// Creates the container of a service, the existing container is removed first when it's recreated
+func applyService(ctx context.Context, rt Runtime, stackName string, service Service, action StackAction) (string, error) {
+	if action == StackRecreate {
+		if err := removeService(ctx, rt, stackName, service.Name); err != nil {
+			return "", err
+		}
+	}
+
+	hash, err := configHash(service.Settings)
+	if err != nil {
+		return "", err
+	}
+
+	settings := *service.Settings
+	settings.Labels = map[string]string{}
+	for key, value := range service.Settings.Labels {
+		settings.Labels[key] = value
+	}
+	settings.Labels[StackLabel] = stackName
+	settings.Labels[ServiceLabel] = service.Name
+	settings.Labels[ConfigHashLabel] = hash
+
+	log.Printf("Applying service %s of stack %s...", service.Name, stackName)
+	return rt.StartContainer(ctx, &settings)
+}
+
+// Removes the containers of a service, its volumes are kept
+func removeService(ctx context.Context, rt Runtime, stackName string, serviceName string) error {
+	containers, err := rt.ListContainers(ctx, ListOptions{
+		Labels: []string{StackLabel + "=" + stackName, ServiceLabel + "=" + serviceName},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, container := range containers {
+		log.Printf("Removing container %s of stack %s...", container.Name, stackName)
+		if err := rt.RemoveContainer(ctx, container.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Fails if a container that is not managed by the stack already uses the name, it would be lost if it was replaced
+func checkUnmanaged(ctx context.Context, rt Runtime, name string) error {
+	_, err := rt.GetContainer(ctx, name)
+	if errdefs.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: container %s already exists and is not managed by a stack", ErrStackConflict, name)
+}
+
+// Returns a hash of the settings of a service, it changes whenever the container would be created differently
+func configHash(settings *Settings) (string, error) {
+	// Maps are encoded with sorted keys, so the same settings always get the same hash
+	encoded, err := json.Marshal(settings)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,146 @@
+package docker
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Layout of a stack written in YAML, for example:
+//
+//	name: home
+//	networks: [home]
+//	volumes: [mosquitto-data]
+//	services:
+//	  - name: mosquitto
+//	    image: eclipse-mosquitto
+//	    tag: "2"
+//	    networkMode: home
+//	    mounts:
+//	      - source: mosquitto-data
+//	        target: /mosquitto/data
+//	  - name: homeassistant
+//	    image: homeassistant/home-assistant
+//	    tag: stable
+//	    networkMode: home
+//	    configDir: /srv/homeassistant
+//	    ports:
+//	      - containerPort: 8123
+type stackFile struct {
+	Name     string        `yaml:"name"`
+	Networks []string      `yaml:"networks"`
+	Volumes  []string      `yaml:"volumes"`
+	Services []serviceFile `yaml:"services"`
+}
+
+type serviceFile struct {
+	Name          string            `yaml:"name"`
+	ContainerName string            `yaml:"containerName"`
+	Image         string            `yaml:"image"`
+	Tag           string            `yaml:"tag"`
+	Env           map[string]string `yaml:"env"`
+	Ports         []struct {
+		HostIP        string `yaml:"hostIp"`
+		HostPort      int    `yaml:"hostPort"`
+		ContainerPort int    `yaml:"containerPort"`
+		Protocol      string `yaml:"protocol"`
+	} `yaml:"ports"`
+	Mounts []struct {
+		Type     string `yaml:"type"`
+		Source   string `yaml:"source"`
+		Target   string `yaml:"target"`
+		ReadOnly bool   `yaml:"readOnly"`
+	} `yaml:"mounts"`
+	RestartPolicy string            `yaml:"restartPolicy"`
+	NetworkMode   string            `yaml:"networkMode"`
+	Labels        map[string]string `yaml:"labels"`
+	ConfigDir     string            `yaml:"configDir"`
+	Devices       []struct {
+		HostPath      string `yaml:"hostPath"`
+		ContainerPath string `yaml:"containerPath"`
+		Permissions   string `yaml:"permissions"`
+	} `yaml:"devices"`
+	Privileged bool `yaml:"privileged"`
+	Readiness  *struct {
+		Mode           string `yaml:"mode"`
+		URL            string `yaml:"url"`
+		TimeoutSeconds int    `yaml:"timeoutSeconds"`
+	} `yaml:"readiness"`
+}
+
+// This function parses a stack written in YAML, unknown fields are rejected so typos don't go unnoticed. Environment
+// variables are sorted so the same file always creates the same containers
+func ParseStack(contents []byte) (*Stack, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(contents))
+	decoder.KnownFields(true)
+
+	var file stackFile
+	if err := decoder.Decode(&file); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: the stack file is empty", ErrInvalidStack)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStack, err)
+	}
+
+	stack := &Stack{
+		Name:     file.Name,
+		Networks: file.Networks,
+		Volumes:  file.Volumes,
+	}
+
+	for _, service := range file.Services {
+		settings := &Settings{
+			ImageName:     service.Image,
+			Tag:           service.Tag,
+			ContainerName: service.ContainerName,
+			EnvVars:       EnvVars(service.Env),
+			RestartPolicy: service.RestartPolicy,
+			NetworkMode:   service.NetworkMode,
+			Labels:        service.Labels,
+			ConfigDir:     service.ConfigDir,
+			Privileged:    service.Privileged,
+		}
+
+		for _, port := range service.Ports {
+			settings.Ports = append(settings.Ports, PortMapping{
+				HostIP:        port.HostIP,
+				HostPort:      port.HostPort,
+				ContainerPort: port.ContainerPort,
+				Protocol:      port.Protocol,
+			})
+		}
+
+		for _, m := range service.Mounts {
+			settings.Mounts = append(settings.Mounts, Mount{
+				Type:     m.Type,
+				Source:   m.Source,
+				Target:   m.Target,
+				ReadOnly: m.ReadOnly,
+			})
+		}
+
+		for _, device := range service.Devices {
+			settings.Devices = append(settings.Devices, Device{
+				HostPath:      device.HostPath,
+				ContainerPath: device.ContainerPath,
+				Permissions:   device.Permissions,
+			})
+		}
+
+		if service.Readiness != nil {
+			settings.Readiness = &Readiness{
+				Mode:    ReadinessMode(service.Readiness.Mode),
+				URL:     service.Readiness.URL,
+				Timeout: time.Duration(service.Readiness.TimeoutSeconds) * time.Second,
+			}
+		}
+
+		stack.Services = append(stack.Services, Service{Name: service.Name, Settings: settings})
+	}
+
+	return stack, nil
+}
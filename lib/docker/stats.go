@@ -0,0 +1,140 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+// Resource usage of a container at a point in time. Memory usage doesn't count the page cache and the network and
+// block I/O are the totals since the container started
+type Stats struct {
+	ContainerID   string
+	ContainerName string
+	Time          time.Time
+	CPUPercent    float64
+	MemoryUsage   uint64
+	MemoryLimit   uint64
+	MemoryPercent float64
+	NetworkRx     uint64
+	NetworkTx     uint64
+	BlockRead     uint64
+	BlockWrite    uint64
+	PIDs          uint64
+}
+
+// This function returns a single sample of the resource usage of a container. Docker waits for a second sample to
+// calculate the CPU usage, so it takes a couple of seconds
+func GetStats(ctx context.Context, rt Runtime, containerName string) (Stats, error) {
+	var stats Stats
+	err := rt.Stats(ctx, containerName, false, func(sample Stats) error {
+		stats = sample
+		return nil
+	})
+
+	return stats, err
+}
+
+// This function calls the handler with the resource usage of a container. Without streaming it's called once,
+// otherwise it's called every time docker samples the container, about once a second, and it only returns once the
+// context is cancelled, the container is removed or the handler fails
+func (e *Engine) Stats(ctx context.Context, containerName string, stream bool, handle func(Stats) error) error {
+	client, err := e.createClient()
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	response, err := client.ContainerStats(ctx, containerName, stream)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	decoder := json.NewDecoder(response.Body)
+	for {
+		var sample types.StatsJSON
+		if err := decoder.Decode(&sample); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		if err := handle(CalculateStats(sample)); err != nil {
+			return err
+		}
+	}
+}
+
+// This function calculates the resource usage from a sample of the docker API the same way docker stats does. The CPU
+// percent is relative to a single CPU, so a container using two CPUs entirely is at 200%
+func CalculateStats(sample types.StatsJSON) Stats {
+	stats := Stats{
+		ContainerID:   sample.ID,
+		ContainerName: strings.TrimPrefix(sample.Name, "/"),
+		Time:          sample.Read,
+		CPUPercent:    cpuPercent(sample.PreCPUStats, sample.CPUStats),
+		MemoryUsage:   memoryUsage(sample.MemoryStats),
+		MemoryLimit:   sample.MemoryStats.Limit,
+		PIDs:          sample.PidsStats.Current,
+	}
+
+	if stats.MemoryLimit != 0 {
+		stats.MemoryPercent = float64(stats.MemoryUsage) / float64(stats.MemoryLimit) * 100
+	}
+
+	for _, network := range sample.Networks {
+		stats.NetworkRx += network.RxBytes
+		stats.NetworkTx += network.TxBytes
+	}
+
+	for _, entry := range sample.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			stats.BlockRead += entry.Value
+		case "write":
+			stats.BlockWrite += entry.Value
+		}
+	}
+
+	return stats
+}
+
+// Returns the CPU usage between the two samples, the first sample of a stream has no previous one so it's zero
+func cpuPercent(previous types.CPUStats, current types.CPUStats) float64 {
+	cpuDelta := float64(current.CPUUsage.TotalUsage) - float64(previous.CPUUsage.TotalUsage)
+	systemDelta := float64(current.SystemUsage) - float64(previous.SystemUsage)
+
+	onlineCPUs := float64(current.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(current.CPUUsage.PercpuUsage))
+	}
+
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+
+	return cpuDelta / systemDelta * onlineCPUs * 100
+}
+
+// Returns the memory used by the container without the inactive page cache, which the kernel can reclaim. Cgroup v1
+// reports it as total_inactive_file and cgroup v2 as inactive_file
+func memoryUsage(memory types.MemoryStats) uint64 {
+	if inactive, ok := memory.Stats["total_inactive_file"]; ok && inactive < memory.Usage {
+		return memory.Usage - inactive
+	}
+
+	if inactive := memory.Stats["inactive_file"]; inactive < memory.Usage {
+		return memory.Usage - inactive
+	}
+
+	return memory.Usage
+}
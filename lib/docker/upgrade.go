@@ -0,0 +1,339 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Returned when the upgraded container doesn't become ready and the previous one is restored
+var ErrUpgradeFailed = errors.New("upgrade failed")
+
+// Settings used to upgrade a container. The image defaults to the one of the current container, so the same tag is
+// pulled again, and the tag replaces the tag of the image
+type UpgradeOptions struct {
+	Image         string
+	Tag           string
+	HealthTimeout time.Duration
+}
+
+// Restarts a container, docker waits for the default timeout before killing it
+func (e *Engine) RestartContainer(ctx context.Context, containerName string) error {
+	client, err := e.createClient()
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	log.Printf("Restarting container %s...", containerName)
+	return client.ContainerRestart(ctx, containerName, container.StopOptions{})
+}
+
+// Recreates a container with a new image keeping the rest of its settings. The current container is kept until the
+// new one becomes ready, if it doesn't the new container is removed and the current one is started again. It returns
+// the ID of the new container
+func (e *Engine) UpgradeContainer(ctx context.Context, containerName string, options UpgradeOptions) (string, error) {
+	client, err := e.createClient()
+	if err != nil {
+		return "", err
+	}
+
+	defer client.Close()
+
+	current, err := client.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return "", err
+	}
+
+	// The inspected config has the defaults of the current image, which must not override the ones of the new image
+	currentImage, _, err := client.ImageInspectWithRaw(ctx, current.Image)
+	if err != nil {
+		return "", err
+	}
+
+	image, err := upgradeImage(current.Config.Image, options)
+	if err != nil {
+		return "", err
+	}
+
+	if err := pullImage(ctx, client, image, logPullProgress(image)); err != nil {
+		return "", err
+	}
+
+	config, hostConfig, networkConfig := snapshotContainer(current, currentImage.Config)
+	config.Image = image
+
+	// Before API 1.44 a container can only be created in one network, it's connected to the rest before starting
+	primaryConfig, extraNetworks := splitNetworks(hostConfig.NetworkMode, networkConfig)
+
+	// The current container is renamed so the new one can take its name
+	name := strings.TrimPrefix(current.Name, "/")
+	previousName := name + "-previous"
+
+	// A container left behind by an upgrade that couldn't clean up would make the rename fail after stopping the
+	// current container, so the upgrade doesn't start until it's removed
+	if _, err := client.ContainerInspect(ctx, previousName); err == nil {
+		return "", errdefs.Conflict(fmt.Errorf("container %s was left by a previous upgrade, remove it before upgrading %s", previousName, name))
+	} else if !errdefs.IsNotFound(err) {
+		return "", err
+	}
+
+	log.Printf("Upgrading container %s from %s to %s...", name, current.Config.Image, image)
+
+	if err := client.ContainerStop(ctx, current.ID, container.StopOptions{}); err != nil {
+		return "", err
+	}
+
+	if err := client.ContainerRename(ctx, current.ID, previousName); err != nil {
+		return "", restorePrevious(ctx, client, current.ID, name, false, "", err)
+	}
+
+	created, err := client.ContainerCreate(ctx, config, hostConfig, primaryConfig, &v1.Platform{}, name)
+	if err != nil {
+		return "", restorePrevious(ctx, client, current.ID, name, true, "", err)
+	}
+
+	for networkName, endpoint := range extraNetworks {
+		if err := client.NetworkConnect(ctx, networkName, created.ID, endpoint); err != nil {
+			return "", restorePrevious(ctx, client, current.ID, name, true, created.ID, err)
+		}
+	}
+
+	if err := client.ContainerStart(ctx, created.ID, types.ContainerStartOptions{}); err != nil {
+		return "", restorePrevious(ctx, client, current.ID, name, true, created.ID, err)
+	}
+
+	if err := waitReady(ctx, client, created.ID, Readiness{Timeout: options.HealthTimeout}); err != nil {
+		return "", restorePrevious(ctx, client, current.ID, name, true, created.ID, err)
+	}
+
+	if err := client.ContainerRemove(ctx, current.ID, types.ContainerRemoveOptions{Force: true}); err != nil {
+		log.Printf("Unable to remove previous container %s: %v", previousName, err)
+	}
+
+	log.Printf("Container %s upgraded to %s! (%s)", name, image, created.ID)
+	return created.ID, nil
+}
+
+// Returns the image of the upgraded container. The tag replaces the one of the current image, while an image of the
+// options that already has a tag or digest can't get another one, like the image of the settings
+func upgradeImage(currentImage string, options UpgradeOptions) (string, error) {
+	if options.Image != "" {
+		return TaggedImage(options.Image, options.Tag)
+	}
+
+	if options.Tag == "" {
+		return currentImage, nil
+	}
+
+	return TaggedImage(imageRepository(currentImage), options.Tag)
+}
+
+// Returns the tag of the image reference, latest if it doesn't have one
+func imageTag(image string) string {
+	repository := imageRepository(image)
+	if len(image) > len(repository) && image[len(repository)] == ':' {
+		return image[len(repository)+1:]
+	}
+
+	return "latest"
+}
+
+// Returns the image reference without its tag or digest
+func imageRepository(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+
+	// A colon after the last slash separates the tag, any other colon belongs to the registry port
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+
+	return image
+}
+
+// Builds the settings to create a copy of the container. Only the settings of the container that differ from the
+// defaults of its image are kept, so the new image brings its own command, environment and labels. Anonymous volumes
+// are mounted by name so the data of the current container carries over to the new one
+func snapshotContainer(current types.ContainerJSON, imageConfig *container.Config) (*container.Config, *container.HostConfig, *network.NetworkingConfig) {
+	config := userConfig(current.Config, imageConfig)
+	hostConfig := *current.HostConfig
+
+	// Docker names the container after its ID unless a hostname is set
+	if strings.HasPrefix(current.ID, config.Hostname) {
+		config.Hostname = ""
+	}
+
+	mounted := map[string]bool{}
+	for _, m := range hostConfig.Mounts {
+		mounted[m.Target] = true
+	}
+	for _, bind := range hostConfig.Binds {
+		if parts := strings.Split(bind, ":"); len(parts) > 1 {
+			mounted[parts[1]] = true
+		}
+	}
+
+	hostConfig.Mounts = append([]mount.Mount{}, hostConfig.Mounts...)
+	for _, m := range current.Mounts {
+		if m.Type == mount.TypeVolume && m.Name != "" && !mounted[m.Destination] {
+			hostConfig.Mounts = append(hostConfig.Mounts, mount.Mount{
+				Type:   mount.TypeVolume,
+				Source: m.Name,
+				Target: m.Destination,
+			})
+		}
+	}
+
+	networkConfig := &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{},
+	}
+	if current.NetworkSettings != nil {
+		for name, endpoint := range current.NetworkSettings.Networks {
+			networkConfig.EndpointsConfig[name] = &network.EndpointSettings{
+				IPAMConfig: endpoint.IPAMConfig,
+				Links:      endpoint.Links,
+				Aliases:    endpoint.Aliases,
+			}
+		}
+	}
+
+	return config, &hostConfig, networkConfig
+}
+
+// Returns the config of the container without the values that come from the defaults of its image
+func userConfig(current *container.Config, image *container.Config) *container.Config {
+	config := *current
+	if image == nil {
+		return &config
+	}
+
+	if reflect.DeepEqual(config.Cmd, image.Cmd) {
+		config.Cmd = nil
+	}
+	if reflect.DeepEqual(config.Entrypoint, image.Entrypoint) {
+		config.Entrypoint = nil
+	}
+	if reflect.DeepEqual(config.Shell, image.Shell) {
+		config.Shell = nil
+	}
+	if reflect.DeepEqual(config.Healthcheck, image.Healthcheck) {
+		config.Healthcheck = nil
+	}
+	if config.WorkingDir == image.WorkingDir {
+		config.WorkingDir = ""
+	}
+	if config.User == image.User {
+		config.User = ""
+	}
+	if config.StopSignal == image.StopSignal {
+		config.StopSignal = ""
+	}
+
+	imageEnv := map[string]bool{}
+	for _, env := range image.Env {
+		imageEnv[env] = true
+	}
+	config.Env = nil
+	for _, env := range current.Env {
+		if !imageEnv[env] {
+			config.Env = append(config.Env, env)
+		}
+	}
+
+	config.Labels = map[string]string{}
+	for key, value := range current.Labels {
+		if imageValue, ok := image.Labels[key]; !ok || imageValue != value {
+			config.Labels[key] = value
+		}
+	}
+
+	config.ExposedPorts = nat.PortSet{}
+	for port := range current.ExposedPorts {
+		if _, ok := image.ExposedPorts[port]; !ok {
+			config.ExposedPorts[port] = struct{}{}
+		}
+	}
+
+	config.Volumes = map[string]struct{}{}
+	for volume := range current.Volumes {
+		if _, ok := image.Volumes[volume]; !ok {
+			config.Volumes[volume] = struct{}{}
+		}
+	}
+
+	return &config
+}
+
+// Splits the networks of the container into the one it's created in, the network of its network mode when it's
+// attached to it, and the ones it's connected to afterwards
+func splitNetworks(mode container.NetworkMode, config *network.NetworkingConfig) (*network.NetworkingConfig, map[string]*network.EndpointSettings) {
+	names := make([]string, 0, len(config.EndpointsConfig))
+	for name := range config.EndpointsConfig {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	primary := string(mode)
+	if mode.IsDefault() {
+		primary = "bridge"
+	}
+	if _, ok := config.EndpointsConfig[primary]; !ok && len(names) > 0 {
+		primary = names[0]
+	}
+
+	primaryConfig := &network.NetworkingConfig{EndpointsConfig: map[string]*network.EndpointSettings{}}
+	extra := map[string]*network.EndpointSettings{}
+	for _, name := range names {
+		if name == primary {
+			primaryConfig.EndpointsConfig[name] = config.EndpointsConfig[name]
+		} else {
+			extra[name] = config.EndpointsConfig[name]
+		}
+	}
+
+	return primaryConfig, extra
+}
+
+// Removes the upgraded container, if it was created, and brings back the previous one, giving it back its original
+// name if it was renamed
+func restorePrevious(ctx context.Context, client *client.Client, previousID string, name string, renamed bool, createdID string, cause error) error {
+	log.Printf("Upgrade of container %s failed, restoring the previous container: %v", name, cause)
+
+	// The rollback must happen even if the request was cancelled
+	ctx = context.Background()
+
+	if createdID != "" {
+		if err := client.ContainerRemove(ctx, createdID, types.ContainerRemoveOptions{Force: true}); err != nil {
+			return fmt.Errorf("%w: %v, unable to remove the new container: %v", ErrUpgradeFailed, cause, err)
+		}
+	}
+
+	if renamed {
+		if err := client.ContainerRename(ctx, previousID, name); err != nil {
+			return fmt.Errorf("%w: %v, unable to rename the previous container: %v", ErrUpgradeFailed, cause, err)
+		}
+	}
+
+	if err := client.ContainerStart(ctx, previousID, types.ContainerStartOptions{}); err != nil {
+		return fmt.Errorf("%w: %v, unable to start the previous container: %v", ErrUpgradeFailed, cause, err)
+	}
+
+	return fmt.Errorf("%w: %v, the previous container was restored", ErrUpgradeFailed, cause)
+}
@@ -0,0 +1,155 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+
+	"github.com/aacuadras/ha-utils/lib/paths"
+)
+
+// Path where home assistant reads its configuration inside the container
+const haConfigPath = "/config"
+
+var (
+	// Returned when the settings of a container are malformed
+	ErrInvalidSettings = errors.New("invalid container settings")
+	// Returned when the settings try to use a host path that is not allowed
+	ErrPathNotAllowed = errors.New("host path not allowed")
+)
+
+// This function validates that the host paths used by the settings can be used. Bind mounts, including the home
+// assistant config directory, must be inside the allowed host root, so no bind mounts are allowed if the root is
+// empty. Devices must be under /dev and the restart policy must be a known one, the check doesn't depend on the
+// backend since every backend accepts the same policies
+func ValidateSettings(settings *Settings, allowedRoot string) error {
+	if _, err := TaggedImage(settings.ImageName, settings.Tag); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSettings, err)
+	}
+
+	if _, err := parseRestartPolicy(settings.RestartPolicy); err != nil {
+		return err
+	}
+
+	if settings.ConfigDir != "" {
+		if err := validateHostPath(settings.ConfigDir, allowedRoot); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range settings.Mounts {
+		if m.Target == "" {
+			return fmt.Errorf("%w: mount of %q is missing its target", ErrInvalidSettings, m.Source)
+		}
+
+		if m.Target == haConfigPath && settings.ConfigDir != "" {
+			return fmt.Errorf("%w: %s is already mounted from the config directory", ErrInvalidSettings, haConfigPath)
+		}
+
+		if m.Type == "bind" || (m.Type == "" && filepath.IsAbs(m.Source)) {
+			if err := validateHostPath(m.Source, allowedRoot); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, device := range settings.Devices {
+		if err := validateDevice(device.HostPath); err != nil {
+			return err
+		}
+	}
+
+	if settings.Readiness != nil {
+		if err := validateReadiness(settings); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Checks that the readiness mode is known and that the HTTP mode has a URL to probe
+func validateReadiness(settings *Settings) error {
+	switch settings.Readiness.Mode {
+	case ReadinessAuto, ReadinessHealthcheck:
+		return nil
+	case ReadinessHTTP:
+	default:
+		return fmt.Errorf("%w: unknown readiness mode %q", ErrInvalidSettings, settings.Readiness.Mode)
+	}
+
+	probeURL, err := readinessURL(settings)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := url.Parse(probeURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: invalid readiness URL %q", ErrInvalidSettings, probeURL)
+	}
+
+	return nil
+}
+
+// Checks that the path is absolute and that, after following its symlinks, it's still inside the root
+func validateHostPath(path string, root string) error {
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("%w: %q is not an absolute path", ErrInvalidSettings, path)
+	}
+
+	if root == "" {
+		return fmt.Errorf("%w: %q, no host root is configured for bind mounts", ErrPathNotAllowed, path)
+	}
+
+	if abs, err := filepath.Abs(root); err == nil {
+		root = abs
+	}
+
+	resolvedRoot, err := resolvePath(root)
+	if err != nil {
+		return err
+	}
+
+	resolvedPath, err := resolvePath(path)
+	if err != nil {
+		return err
+	}
+
+	if !paths.IsWithin(resolvedRoot, resolvedPath) {
+		return fmt.Errorf("%w: %q is outside of %q", ErrPathNotAllowed, path, root)
+	}
+
+	return nil
+}
+
+// Checks that the device is inside /dev, symlinks such as /dev/serial/by-id are allowed as long as they point to a
+// device
+func validateDevice(path string) error {
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("%w: device %q is not an absolute path", ErrInvalidSettings, path)
+	}
+
+	resolvedPath, err := resolvePath(path)
+	if err != nil {
+		return err
+	}
+
+	if !paths.IsWithin("/dev", filepath.Clean(path)) || !paths.IsWithin("/dev", resolvedPath) {
+		return fmt.Errorf("%w: device %q is outside of /dev", ErrPathNotAllowed, path)
+	}
+
+	return nil
+}
+
+// Follows the symlinks of an absolute path, parts of the path that don't exist yet are kept as they are. Docker doesn't
+// create missing bind mount sources, the container fails to start instead, but the path is still checked so it can't
+// be created later through a symlink that leads outside of the root
+func resolvePath(path string) (string, error) {
+	resolved, err := paths.Resolve(path)
+	if errors.Is(err, paths.ErrTooManySymlinks) {
+		return "", fmt.Errorf("%w: %v", ErrInvalidSettings, err)
+	}
+
+	return resolved, err
+}
@@ -0,0 +1,69 @@
+package filediff
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// This function writes the contents to a temporary file in the same directory, flushes it to disk and renames it over
+// the destination, so readers see either the previous or the new contents but never a partial write. Existing files
+// keep their permissions
+func writeFileAtomic(fileName string, contents []byte, perm os.FileMode) error {
+	if info, err := os.Stat(fileName); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	dir := filepath.Dir(fileName)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(fileName)+".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	// Clean up the temporary file if anything fails before it's renamed
+	renamed := false
+	defer func() {
+		if !renamed {
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), fileName); err != nil {
+		return err
+	}
+	renamed = true
+
+	return syncDir(dir)
+}
+
+// Flushes the directory so the rename survives a crash
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	// Some file systems don't support syncing directories, the rename already happened at this point
+	d.Sync()
+
+	return nil
+}
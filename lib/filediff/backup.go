@@ -0,0 +1,180 @@
+package filediff
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Layout of the revisions, it sorts in chronological order
+const revisionFormat = "20060102T150405.000000000Z"
+
+// Returned when a file doesn't have the requested revision
+var ErrBackupNotFound = errors.New("backup not found")
+
+// Stored version of a file before it was replaced
+type Backup struct {
+	FileName  string
+	Revision  string
+	CreatedAt time.Time
+	Size      int64
+}
+
+// Keeps the previous versions of the files in a directory, every file has its own directory named after its path
+// with one file per revision. Only the newest revisions up to the retention are kept, a retention of zero or less
+// keeps all of them
+type BackupStore struct {
+	dir       string
+	retention int
+}
+
+// Returns a backup store that saves the revisions in the directory
+func NewBackupStore(dir string, retention int) *BackupStore {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+
+	return &BackupStore{
+		dir:       filepath.Clean(dir),
+		retention: retention,
+	}
+}
+
+// Returns the directory where the revisions are stored
+func (b *BackupStore) Dir() string {
+	return b.dir
+}
+
+// This function saves the contents as a new revision of the file and removes the revisions over the retention
+func (b *BackupStore) Save(fileName string, contents []byte) (Backup, error) {
+	dir := b.fileDir(fileName)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return Backup{}, err
+	}
+
+	// Revisions are unique, two backups of the same file in the same nanosecond get consecutive timestamps
+	createdAt := time.Now().UTC()
+	var revision string
+	for {
+		revision = createdAt.Format(revisionFormat)
+		file, err := os.OpenFile(filepath.Join(dir, revision), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if os.IsExist(err) {
+			createdAt = createdAt.Add(time.Nanosecond)
+			continue
+		}
+		if err != nil {
+			return Backup{}, err
+		}
+		file.Close()
+		break
+	}
+
+	if err := writeFileAtomic(filepath.Join(dir, revision), contents, 0600); err != nil {
+		os.Remove(filepath.Join(dir, revision))
+		return Backup{}, err
+	}
+
+	if err := b.prune(fileName); err != nil {
+		return Backup{}, err
+	}
+
+	return Backup{
+		FileName:  fileName,
+		Revision:  revision,
+		CreatedAt: createdAt,
+		Size:      int64(len(contents)),
+	}, nil
+}
+
+// This function lists the revisions of a file from newest to oldest
+func (b *BackupStore) List(fileName string) ([]Backup, error) {
+	entries, err := os.ReadDir(b.fileDir(fileName))
+	if os.IsNotExist(err) {
+		return []Backup{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	backups := []Backup{}
+	for _, entry := range entries {
+		createdAt, err := time.Parse(revisionFormat, entry.Name())
+		if err != nil || entry.IsDir() {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		backups = append(backups, Backup{
+			FileName:  fileName,
+			Revision:  entry.Name(),
+			CreatedAt: createdAt,
+			Size:      info.Size(),
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Revision > backups[j].Revision
+	})
+
+	return backups, nil
+}
+
+// This function returns the contents of a revision of the file
+func (b *BackupStore) Load(fileName string, revision string) ([]byte, error) {
+	if _, err := time.Parse(revisionFormat, revision); err != nil {
+		return nil, fmt.Errorf("%w: %s has no revision %q", ErrBackupNotFound, fileName, revision)
+	}
+
+	contents, err := os.ReadFile(filepath.Join(b.fileDir(fileName), revision))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s has no revision %q", ErrBackupNotFound, fileName, revision)
+	}
+
+	return contents, err
+}
+
+// This function deletes a revision of the file
+func (b *BackupStore) Delete(fileName string, revision string) error {
+	if _, err := time.Parse(revisionFormat, revision); err != nil {
+		return fmt.Errorf("%w: %s has no revision %q", ErrBackupNotFound, fileName, revision)
+	}
+
+	err := os.Remove(filepath.Join(b.fileDir(fileName), revision))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s has no revision %q", ErrBackupNotFound, fileName, revision)
+	}
+
+	return err
+}
+
+// Removes the oldest revisions of the file that are over the retention
+func (b *BackupStore) prune(fileName string) error {
+	if b.retention <= 0 {
+		return nil
+	}
+
+	backups, err := b.List(fileName)
+	if err != nil {
+		return err
+	}
+
+	for i := b.retention; i < len(backups); i++ {
+		if err := os.Remove(filepath.Join(b.fileDir(fileName), backups[i].Revision)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Directory of the revisions of a file, the file name is relative to the root so it can't leave the store
+func (b *BackupStore) fileDir(fileName string) string {
+	return filepath.Join(b.dir, filepath.Clean(fileName))
+}
@@ -0,0 +1,276 @@
+package filediff
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Number of unchanged lines shown around every change, the same default used by diff -u
+const diffContext = 3
+
+// A contiguous block of changes between two versions of a file. Every line is prefixed with ' ' when it's unchanged,
+// '-' when it's only in the current file and '+' when it's only in the new contents. Lines are 1-indexed
+type Hunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	Lines    []string
+}
+
+// Result of comparing the contents of a file in the system against new contents
+type Diff struct {
+	IsSame  bool
+	Unified string
+	Hunks   []Hunk
+}
+
+type editKind int
+
+const (
+	editEqual editKind = iota
+	editDelete
+	editInsert
+)
+
+// A single step of the edit script, oldLine and newLine are the 0-indexed positions in each file where the step
+// happens
+type edit struct {
+	kind    editKind
+	oldLine int
+	newLine int
+	text    string
+}
+
+// This function computes the line based differences between the current and the new contents of a file, the name is
+// only used for the headers of the unified diff
+func DiffContents(fileName string, current []byte, updated []byte) Diff {
+	oldLines := splitLines(string(current))
+	newLines := splitLines(string(updated))
+
+	hunks := buildHunks(diffLines(oldLines, newLines))
+	if len(hunks) == 0 {
+		return Diff{IsSame: true}
+	}
+
+	return Diff{
+		IsSame:  false,
+		Unified: formatUnified(fileName, hunks),
+		Hunks:   hunks,
+	}
+}
+
+// Splits the text in lines keeping the line terminators, so a missing newline at the end of the file is reported as
+// a change
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// Computes the shortest edit script between two lists of lines using the Myers algorithm
+func diffLines(a []string, b []string) []edit {
+	n, m := len(a), len(b)
+	maxSteps := n + m
+	offset := maxSteps + 1
+	v := make([]int, 2*maxSteps+3)
+
+	// Every entry keeps the furthest reaching paths (diagonals -d to d) before the step d is taken, they are used
+	// to walk the path back once the end is reached
+	var trace [][]int
+
+	for d := 0; d <= maxSteps; d++ {
+		snapshot := make([]int, 2*d+1)
+		copy(snapshot, v[offset-d:offset+d+1])
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Walks the Myers trace from the end of both files to the start and returns the edits in order
+func backtrack(trace [][]int, a []string, b []string) []edit {
+	x, y := len(a), len(b)
+	var edits []edit
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		at := func(k int) int {
+			return v[k+d]
+		}
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		prevX := 0
+		if d > 0 {
+			prevX = at(prevK)
+		}
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{kind: editEqual, oldLine: x, newLine: y, text: a[x]})
+		}
+
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, edit{kind: editInsert, oldLine: x, newLine: prevY, text: b[prevY]})
+			} else {
+				edits = append(edits, edit{kind: editDelete, oldLine: prevX, newLine: y, text: a[prevX]})
+			}
+		}
+
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+
+	return edits
+}
+
+// Groups the edit script in hunks, changes that are close enough to share their context lines end up in the same
+// hunk
+func buildHunks(edits []edit) []Hunk {
+	var hunks []Hunk
+
+	for i := 0; i < len(edits); {
+		if edits[i].kind == editEqual {
+			i++
+			continue
+		}
+
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+
+		// Extend the hunk until there are more unchanged lines than the context of both sides can cover
+		end := i
+		for end < len(edits) {
+			if edits[end].kind != editEqual {
+				end++
+				continue
+			}
+
+			run := end
+			for run < len(edits) && edits[run].kind == editEqual {
+				run++
+			}
+
+			if run == len(edits) || run-end > 2*diffContext {
+				end += diffContext
+				if end > len(edits) {
+					end = len(edits)
+				}
+				break
+			}
+
+			end = run
+		}
+
+		hunks = append(hunks, newHunk(edits[start:end]))
+		i = end
+	}
+
+	return hunks
+}
+
+func newHunk(edits []edit) Hunk {
+	hunk := Hunk{
+		OldStart: edits[0].oldLine,
+		NewStart: edits[0].newLine,
+	}
+
+	for _, e := range edits {
+		line := strings.TrimSuffix(e.text, "\n")
+		switch e.kind {
+		case editEqual:
+			hunk.OldLines++
+			hunk.NewLines++
+			line = " " + line
+		case editDelete:
+			hunk.OldLines++
+			line = "-" + line
+		case editInsert:
+			hunk.NewLines++
+			line = "+" + line
+		}
+		hunk.Lines = append(hunk.Lines, line)
+
+		if !strings.HasSuffix(e.text, "\n") {
+			hunk.Lines = append(hunk.Lines, `\ No newline at end of file`)
+		}
+	}
+
+	// Empty ranges point to the line right before the change, any other range starts at its first line
+	if hunk.OldLines > 0 {
+		hunk.OldStart++
+	}
+	if hunk.NewLines > 0 {
+		hunk.NewStart++
+	}
+
+	return hunk
+}
+
+// Renders the hunks with the unified diff format
+func formatUnified(fileName string, hunks []Hunk) string {
+	name := strings.TrimPrefix(filepath.ToSlash(filepath.Clean(fileName)), "/")
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- a/%s\n+++ b/%s\n", name, name)
+	for _, hunk := range hunks {
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", formatRange(hunk.OldStart, hunk.OldLines), formatRange(hunk.NewStart, hunk.NewLines))
+		for _, line := range hunk.Lines {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+func formatRange(start int, lines int) string {
+	if lines == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+
+	return fmt.Sprintf("%d,%d", start, lines)
+}
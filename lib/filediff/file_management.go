@@ -3,14 +3,47 @@ package filediff
 import (
 	"bytes"
 	b64 "encoding/base64"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+)
+
+// Controls what happens when the file being written exists or not
+type WriteMode int
+
+const (
+	// Creates the file if it doesn't exist and replaces it otherwise
+	Upsert WriteMode = iota
+	// Only creates the file, it fails if the file exists
+	CreateOnly
+	// Only replaces the file, it fails if the file doesn't exist
+	ReplaceOnly
+)
+
+// What happened to a file after writing it
+type Action int
+
+const (
+	Unchanged Action = iota
+	Created
+	Updated
+)
+
+var (
+	// Returned when the contents are not valid base64
+	ErrInvalidContent = errors.New("invalid content")
+	// Returned when a file can only be created but it already exists
+	ErrFileExists = errors.New("file already exists")
+	// Returned when a file can only be replaced but it doesn't exist
+	ErrFileNotFound = errors.New("file does not exist")
 )
 
 // This function decodes the contents of a base64 encoded file
 func decodeFile(fileContents string) ([]byte, error) {
 	decodedContents, err := b64.StdEncoding.DecodeString(fileContents)
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, fmt.Errorf("%w: %v", ErrInvalidContent, err)
 	}
 
 	return decodedContents, nil
@@ -38,13 +71,58 @@ func ReplaceFile(fileName string, encodedFileContents string) error {
 		return err
 	}
 
-	if err := os.WriteFile(fileName, fileContents, 0600); err != nil {
+	if err := writeFileAtomic(fileName, fileContents, 0600); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// Writes the contents to a file following the write mode, the backup function receives the current contents of the
+// file right before they are replaced
+func writeFile(fileName string, fileContents []byte, mode WriteMode, backup func([]byte) error) (Action, error) {
+	currentContents, err := os.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		if mode == ReplaceOnly {
+			return Unchanged, fmt.Errorf("%w: %s", ErrFileNotFound, fileName)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(fileName), 0700); err != nil {
+			return Unchanged, err
+		}
+
+		if err := writeFileAtomic(fileName, fileContents, 0600); err != nil {
+			return Unchanged, err
+		}
+
+		return Created, nil
+	}
+
+	if err != nil {
+		return Unchanged, err
+	}
+
+	if mode == CreateOnly {
+		return Unchanged, fmt.Errorf("%w: %s", ErrFileExists, fileName)
+	}
+
+	if bytes.Equal(currentContents, fileContents) {
+		return Unchanged, nil
+	}
+
+	if backup != nil {
+		if err := backup(currentContents); err != nil {
+			return Unchanged, err
+		}
+	}
+
+	if err := writeFileAtomic(fileName, fileContents, 0600); err != nil {
+		return Unchanged, err
+	}
+
+	return Updated, nil
+}
+
 // This function creates a test file to run integration tests against grpc operations
 func CreateTestFile(content string, fileName string) error {
 	// If test directory does not exist, create it
@@ -0,0 +1,114 @@
+package filediff
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+)
+
+// What would happen to a file if it was written, along with the diff between its current and new contents. Files
+// that don't exist yet are diffed against an empty file
+type PlannedFile struct {
+	FileName string
+	Action   Action
+	Diff     Diff
+}
+
+// This function returns what writing the encoded contents to a file in the root would do without writing anything.
+// The file goes through the same validations as a write, so a plan that succeeds means the write would too
+func (r *Root) PlanFile(fileName string, encodedFileContents string, mode WriteMode, checks ...Check) (PlannedFile, error) {
+	path, err := r.Resolve(fileName)
+	if err != nil {
+		return PlannedFile{}, err
+	}
+
+	fileContents, err := decodeFile(encodedFileContents)
+	if err != nil {
+		return PlannedFile{}, err
+	}
+
+	if err := runChecks(r.relativeName(path), fileContents, checks); err != nil {
+		return PlannedFile{}, err
+	}
+
+	current, exists, err := readCurrent(path)
+	if err != nil {
+		return PlannedFile{}, err
+	}
+
+	return planContents(r.relativeName(path), current, exists, fileContents, mode)
+}
+
+// This function returns what committing the transaction would do to each staged file without writing anything. Files
+// staged more than once are compared with the contents staged before them. The verification of the transaction
+// doesn't run, since it needs the files to be written
+func (t *Transaction) Plan() ([]PlannedFile, error) {
+	if t.done {
+		return nil, ErrTransactionDone
+	}
+
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	staged := map[string][]byte{}
+	planned := make([]PlannedFile, 0, len(t.staged))
+	for i, file := range t.staged {
+		current, exists := staged[file.path]
+		if !exists {
+			var err error
+			current, exists, err = readCurrent(file.path)
+			if err != nil {
+				return nil, &TransactionError{Index: i, FileName: file.fileName, Err: err}
+			}
+		}
+
+		plan, err := planContents(t.root.relativeName(file.path), current, exists, file.contents, file.mode)
+		if err != nil {
+			return nil, &TransactionError{Index: i, FileName: file.fileName, Err: err}
+		}
+
+		planned = append(planned, plan)
+		staged[file.path] = file.contents
+	}
+
+	return planned, nil
+}
+
+// Reads the current contents of a file, missing files are not an error
+func readCurrent(path string) ([]byte, bool, error) {
+	contents, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return contents, true, nil
+}
+
+// Decides what writing the contents would do following the same rules as writeFile
+func planContents(fileName string, current []byte, exists bool, contents []byte, mode WriteMode) (PlannedFile, error) {
+	plan := PlannedFile{
+		FileName: fileName,
+		Diff:     DiffContents(fileName, current, contents),
+	}
+
+	switch {
+	case !exists && mode == ReplaceOnly:
+		return PlannedFile{}, fmt.Errorf("%w: %s", ErrFileNotFound, fileName)
+	case !exists:
+		plan.Action = Created
+		// An empty file is still created even if the diff has no lines
+		plan.Diff.IsSame = false
+	case mode == CreateOnly:
+		return PlannedFile{}, fmt.Errorf("%w: %s", ErrFileExists, fileName)
+	case bytes.Equal(current, contents):
+		plan.Action = Unchanged
+	default:
+		plan.Action = Updated
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,223 @@
+package filediff
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/aacuadras/ha-utils/lib/paths"
+)
+
+var (
+	// Returned when the file name is malformed or tries to leave the root with ..
+	ErrInvalidPath = errors.New("invalid path")
+	// Returned when the file name points, directly or through a symlink, outside of the root
+	ErrOutsideRoot = errors.New("path outside of the file root")
+)
+
+// Validation of the contents of a file before it's written, the file name is relative to the root
+type Check func(fileName string, contents []byte) error
+
+// Directory that confines every file operation, file names are resolved against it and can't point outside of it.
+// When the root has a backup store, the previous contents of the files are saved there before they are replaced
+type Root struct {
+	mu      sync.Mutex
+	dir     string
+	backups *BackupStore
+}
+
+// Returns a root for the directory, relative directories are resolved against the current working directory
+func NewRoot(dir string) *Root {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+
+	return &Root{dir: filepath.Clean(dir)}
+}
+
+// Returns the absolute path of the root
+func (r *Root) Dir() string {
+	return r.dir
+}
+
+// Sets the store used to save the previous contents of the files. If the store is inside the root, its files can't
+// be accessed as regular files
+func (r *Root) SetBackupStore(store *BackupStore) {
+	r.backups = store
+}
+
+// This function returns the absolute path of a file inside the root. Relative names are joined to the root and
+// absolute names must already be inside of it, in both cases the symlinks of the path can't lead outside of the root
+func (r *Root) Resolve(fileName string) (string, error) {
+	if fileName == "" || strings.ContainsRune(fileName, 0) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPath, fileName)
+	}
+
+	var path string
+	if filepath.IsAbs(fileName) {
+		path = filepath.Clean(fileName)
+		if !paths.IsWithin(r.dir, path) {
+			return "", fmt.Errorf("%w: %q", ErrOutsideRoot, fileName)
+		}
+	} else {
+		rel := filepath.Clean(fileName)
+		if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", fmt.Errorf("%w: %q escapes the file root", ErrInvalidPath, fileName)
+		}
+		path = filepath.Join(r.dir, rel)
+	}
+
+	resolvedRoot, err := filepath.EvalSymlinks(r.dir)
+	if err != nil {
+		return "", err
+	}
+
+	resolvedPath, err := paths.Resolve(path)
+	if errors.Is(err, paths.ErrTooManySymlinks) {
+		return "", fmt.Errorf("%w: %v", ErrInvalidPath, err)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if !paths.IsWithin(resolvedRoot, resolvedPath) {
+		return "", fmt.Errorf("%w: %q links outside of the file root", ErrOutsideRoot, fileName)
+	}
+
+	if r.backups != nil && (paths.IsWithin(r.backups.Dir(), path) || paths.IsWithin(r.backups.Dir(), resolvedPath)) {
+		return "", fmt.Errorf("%w: %q is reserved for backups", ErrOutsideRoot, fileName)
+	}
+
+	return path, nil
+}
+
+// Returns the name of the path relative to the root, it's used to label the files in the diffs
+func (r *Root) relativeName(path string) string {
+	rel, err := filepath.Rel(r.dir, path)
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
+
+// This function checks if the contents of a file in the root match the encoded contents
+func (r *Root) IsSameFile(fileName string, encodedContent string) (bool, error) {
+	path, err := r.Resolve(fileName)
+	if err != nil {
+		return true, err
+	}
+
+	return IsSameFile(path, encodedContent)
+}
+
+// This function returns the line based differences between a file in the root and the encoded contents, a file that
+// doesn't exist yet is compared as an empty file so it can be previewed before it's created
+func (r *Root) DiffFile(fileName string, encodedContent string) (Diff, error) {
+	path, err := r.Resolve(fileName)
+	if err != nil {
+		return Diff{}, err
+	}
+
+	decodedContent, err := decodeFile(encodedContent)
+	if err != nil {
+		return Diff{}, err
+	}
+
+	fileContents, _, err := readCurrent(path)
+	if err != nil {
+		return Diff{}, err
+	}
+
+	return DiffContents(r.relativeName(path), fileContents, decodedContent), nil
+}
+
+// This function replaces the contents of an existing file in the root
+func (r *Root) ReplaceFile(fileName string, encodedFileContents string) error {
+	_, err := r.WriteFile(fileName, encodedFileContents, ReplaceOnly)
+	return err
+}
+
+// This function writes the encoded contents to a file in the root following the write mode, the previous contents
+// are saved in the backup store. The file is only written if its contents pass every check
+func (r *Root) WriteFile(fileName string, encodedFileContents string, mode WriteMode, checks ...Check) (Action, error) {
+	path, err := r.Resolve(fileName)
+	if err != nil {
+		return Unchanged, err
+	}
+
+	fileContents, err := decodeFile(encodedFileContents)
+	if err != nil {
+		return Unchanged, err
+	}
+
+	if err := runChecks(r.relativeName(path), fileContents, checks); err != nil {
+		return Unchanged, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return writeFile(path, fileContents, mode, r.backup(path))
+}
+
+// This function lists the stored revisions of a file in the root from newest to oldest
+func (r *Root) ListBackups(fileName string) ([]Backup, error) {
+	path, err := r.Resolve(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.backups == nil {
+		return []Backup{}, nil
+	}
+
+	return r.backups.List(r.relativeName(path))
+}
+
+// This function restores a file in the root to one of its stored revisions, the contents being replaced are saved as
+// a new revision so the restore can be undone
+func (r *Root) RestoreFile(fileName string, revision string) (Action, error) {
+	path, err := r.Resolve(fileName)
+	if err != nil {
+		return Unchanged, err
+	}
+
+	if r.backups == nil {
+		return Unchanged, fmt.Errorf("%w: backups are disabled", ErrBackupNotFound)
+	}
+
+	fileContents, err := r.backups.Load(r.relativeName(path), revision)
+	if err != nil {
+		return Unchanged, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return writeFile(path, fileContents, Upsert, r.backup(path))
+}
+
+// Returns the function that saves the current contents of the file before they are replaced
+func (r *Root) backup(path string) func([]byte) error {
+	if r.backups == nil {
+		return nil
+	}
+
+	return func(contents []byte) error {
+		_, err := r.backups.Save(r.relativeName(path), contents)
+		return err
+	}
+}
+
+func runChecks(fileName string, contents []byte, checks []Check) error {
+	for _, check := range checks {
+		if err := check(fileName, contents); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
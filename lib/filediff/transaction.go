@@ -0,0 +1,295 @@
+package filediff
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Returned when a transaction is committed after it was already committed or rolled back
+var ErrTransactionDone = errors.New("transaction already finished")
+
+// Error of a transaction, it keeps the position of the staged file that made the whole transaction fail. The index is
+// -1 when the transaction failed its verification after every file was written. The rollback errors are the files that
+// couldn't be restored, when there are any the files are left half written
+type TransactionError struct {
+	Index        int
+	FileName     string
+	Err          error
+	RollbackErrs []error
+}
+
+func (e *TransactionError) Error() string {
+	msg := e.Err.Error()
+	if e.FileName != "" {
+		msg = fmt.Sprintf("%s: %v", e.FileName, e.Err)
+	}
+
+	for _, err := range e.RollbackErrs {
+		msg += fmt.Sprintf("; rollback failed: %v", err)
+	}
+
+	return msg
+}
+
+// Reports if every file written by the transaction was restored
+func (e *TransactionError) RolledBack() bool {
+	return len(e.RollbackErrs) == 0
+}
+
+func (e *TransactionError) Unwrap() error {
+	return e.Err
+}
+
+// Set of files written all together, files are staged in memory and only written when the transaction is committed.
+// If any of them fails, the files that were already written are restored to their previous contents
+type Transaction struct {
+	root   *Root
+	staged []stagedFile
+	verify func() error
+	err    *TransactionError
+	done   bool
+}
+
+type stagedFile struct {
+	fileName string
+	path     string
+	contents []byte
+	mode     WriteMode
+}
+
+// Previous state of a file written by a transaction, used to undo the write. The revision is the backup saved when
+// the file was replaced
+type appliedFile struct {
+	path        string
+	existed     bool
+	contents    []byte
+	createdDirs []string
+	revision    string
+}
+
+// Starts a transaction on the root
+func (r *Root) Begin() *Transaction {
+	return &Transaction{root: r}
+}
+
+// This function adds a file to the transaction after validating its path, contents and mode and running the checks
+// on its contents. The first file that fails makes the whole transaction fail when it's committed
+func (t *Transaction) Stage(fileName string, encodedFileContents string, mode WriteMode, checks ...Check) error {
+	file := stagedFile{fileName: fileName, mode: mode}
+	t.staged = append(t.staged, file)
+	index := len(t.staged) - 1
+
+	err := t.stage(&t.staged[index], encodedFileContents, checks)
+	if err != nil && t.err == nil {
+		t.err = &TransactionError{Index: index, FileName: fileName, Err: err}
+	}
+
+	return err
+}
+
+func (t *Transaction) stage(file *stagedFile, encodedFileContents string, checks []Check) error {
+	path, err := t.root.Resolve(file.fileName)
+	if err != nil {
+		return err
+	}
+	file.path = path
+
+	contents, err := decodeFile(encodedFileContents)
+	if err != nil {
+		return err
+	}
+	file.contents = contents
+
+	if err := runChecks(t.root.relativeName(path), contents, checks); err != nil {
+		return err
+	}
+
+	// Files staged earlier in the transaction count as existing files
+	exists := t.isStaged(path, len(t.staged)-1)
+	if !exists {
+		if _, err := os.Stat(path); err == nil {
+			exists = true
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if exists && file.mode == CreateOnly {
+		return fmt.Errorf("%w: %s", ErrFileExists, file.fileName)
+	}
+	if !exists && file.mode == ReplaceOnly {
+		return fmt.Errorf("%w: %s", ErrFileNotFound, file.fileName)
+	}
+
+	return nil
+}
+
+// Checks if the path was staged before the position
+func (t *Transaction) isStaged(path string, before int) bool {
+	for _, file := range t.staged[:before] {
+		if file.path == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sets a verification that runs once every file is written, such as checking the whole configuration. If it fails,
+// the transaction is rolled back
+func (t *Transaction) Verify(verify func() error) {
+	t.verify = verify
+}
+
+// Returns the number of staged files
+func (t *Transaction) Len() int {
+	return len(t.staged)
+}
+
+// This function writes every staged file and returns what happened to each one in the order they were staged. If a
+// file failed to stage or to be written, or the verification fails after the files changed, every file written by the
+// transaction is restored, the backups it saved are deleted and a TransactionError is returned with the files that
+// couldn't be restored
+func (t *Transaction) Commit() ([]Action, error) {
+	if t.done {
+		return nil, ErrTransactionDone
+	}
+	t.done = true
+
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	t.root.mu.Lock()
+	defer t.root.mu.Unlock()
+
+	actions := make([]Action, 0, len(t.staged))
+	applied := make([]appliedFile, 0, len(t.staged))
+	for i, file := range t.staged {
+		previous, err := snapshot(file.path)
+		if err != nil {
+			return nil, t.fail(i, file.fileName, err, applied)
+		}
+
+		action, err := writeFile(file.path, file.contents, file.mode, t.backup(&previous))
+		if err != nil {
+			// The directories created for the failed file are removed along with the rest of the transaction
+			return nil, t.fail(i, file.fileName, err, append(applied, previous))
+		}
+
+		actions = append(actions, action)
+		applied = append(applied, previous)
+	}
+
+	if t.verify != nil && hasChanges(actions) {
+		if err := t.verify(); err != nil {
+			return nil, t.fail(-1, "", err, applied)
+		}
+	}
+
+	return actions, nil
+}
+
+// Restores the files written by the transaction and returns its error along with the files that couldn't be restored
+func (t *Transaction) fail(index int, fileName string, err error, applied []appliedFile) *TransactionError {
+	return &TransactionError{Index: index, FileName: fileName, Err: err, RollbackErrs: t.rollback(applied)}
+}
+
+// Returns the function that saves the current contents of the file in the backup store, the revision is kept in the
+// applied file so it can be deleted if the transaction is rolled back
+func (t *Transaction) backup(file *appliedFile) func([]byte) error {
+	if t.root.backups == nil {
+		return nil
+	}
+
+	return func(contents []byte) error {
+		saved, err := t.root.backups.Save(t.root.relativeName(file.path), contents)
+		if err != nil {
+			return err
+		}
+
+		file.revision = saved.Revision
+		return nil
+	}
+}
+
+// This function discards the staged files, nothing is written
+func (t *Transaction) Rollback() {
+	t.done = true
+	t.staged = nil
+}
+
+func hasChanges(actions []Action) bool {
+	for _, action := range actions {
+		if action != Unchanged {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Saves the current state of the file and the parent directories that don't exist yet
+func snapshot(path string) (appliedFile, error) {
+	previous := appliedFile{path: path}
+
+	contents, err := os.ReadFile(path)
+	if err == nil {
+		previous.existed = true
+		previous.contents = contents
+		return previous, nil
+	}
+
+	if !os.IsNotExist(err) {
+		return previous, err
+	}
+
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(dir); err == nil || filepath.Dir(dir) == dir {
+			break
+		}
+		previous.createdDirs = append(previous.createdDirs, dir)
+	}
+
+	return previous, nil
+}
+
+// Restores the files written by a transaction in the opposite order they were written and deletes the backups saved
+// for them. A backup is only deleted once its file is restored, so the previous contents aren't lost if it fails
+func (t *Transaction) rollback(applied []appliedFile) []error {
+	var errs []error
+	for i := len(applied) - 1; i >= 0; i-- {
+		file := applied[i]
+		name := t.root.relativeName(file.path)
+
+		if !file.existed {
+			if err := os.Remove(file.path); err != nil && !os.IsNotExist(err) {
+				errs = append(errs, fmt.Errorf("unable to remove %s: %w", name, err))
+				continue
+			}
+
+			for _, dir := range file.createdDirs {
+				if err := os.Remove(dir); err != nil && !os.IsNotExist(err) {
+					errs = append(errs, fmt.Errorf("unable to remove %s: %w", t.root.relativeName(dir), err))
+					break
+				}
+			}
+			continue
+		}
+
+		if err := writeFileAtomic(file.path, file.contents, 0600); err != nil {
+			errs = append(errs, fmt.Errorf("unable to restore %s: %w", name, err))
+			continue
+		}
+
+		if file.revision != "" {
+			if err := t.root.backups.Delete(name, file.revision); err != nil {
+				errs = append(errs, fmt.Errorf("unable to delete the backup %s of %s: %w", file.revision, name, err))
+			}
+		}
+	}
+
+	return errs
+}
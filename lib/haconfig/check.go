@@ -0,0 +1,32 @@
+package haconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aacuadras/ha-utils/lib/docker"
+)
+
+// Command that validates the whole configuration of home assistant without restarting it
+var checkConfigCommand = []string{"hass", "--script", "check_config", "--config", "/config"}
+
+// Returned when home assistant rejects its configuration
+var ErrCheckFailed = errors.New("home assistant config check failed")
+
+// This function runs the home assistant config check inside the container of the runtime, it fails if home assistant
+// finds any error in the configuration and returns the output of the check
+func CheckConfig(ctx context.Context, rt docker.Runtime, containerName string) error {
+	result, err := docker.ExecCommand(ctx, rt, containerName, checkConfigCommand)
+	if err != nil {
+		return err
+	}
+
+	if result.ExitCode != 0 {
+		output := strings.TrimSpace(result.Stdout + "\n" + result.Stderr)
+		return fmt.Errorf("%w (exit code %d): %s", ErrCheckFailed, result.ExitCode, output)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package haconfig
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Tags that home assistant adds to its yaml loader, the value of every one of them is a scalar
+var haTags = map[string]bool{
+	"!include":                 true,
+	"!include_dir_list":        true,
+	"!include_dir_named":       true,
+	"!include_dir_merge_list":  true,
+	"!include_dir_merge_named": true,
+	"!secret":                  true,
+	"!env_var":                 true,
+	"!input":                   true,
+}
+
+// Position of the syntax errors reported by the yaml parser
+var syntaxErrorLine = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// Returned when a configuration file is not valid
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+// Problem found in a yaml file, the column is zero when the parser doesn't report it
+type Error struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e Error) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+	}
+
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// This function checks that the contents are well formed yaml that home assistant can load. Besides the syntax, it
+// checks that only home assistant tags are used, that they are applied to a value and that mappings don't repeat
+// keys
+func ValidateYAML(contents []byte) []Error {
+	var document yaml.Node
+	if err := yaml.Unmarshal(contents, &document); err != nil {
+		return []Error{syntaxError(err)}
+	}
+
+	var errs []Error
+	walk(&document, &errs)
+
+	return errs
+}
+
+// This function validates the yaml contents of a file and joins all the problems found in a single error
+func ValidateFile(fileName string, contents []byte) error {
+	errs := ValidateYAML(contents)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	// Problems are reported as file:line:column: message
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		if err.Column == 0 {
+			messages = append(messages, fmt.Sprintf("%s:%d: %s", fileName, err.Line, err.Message))
+		} else {
+			messages = append(messages, fmt.Sprintf("%s:%d:%d: %s", fileName, err.Line, err.Column, err.Message))
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrInvalidConfig, strings.Join(messages, "; "))
+}
+
+// Converts the errors of the yaml parser, they only have the line where the problem was found
+func syntaxError(err error) Error {
+	message := err.Error()
+	if match := syntaxErrorLine.FindStringSubmatch(message); match != nil {
+		line, _ := strconv.Atoi(match[1])
+		return Error{Line: line, Message: match[2]}
+	}
+
+	return Error{Line: 1, Message: strings.TrimPrefix(message, "yaml: ")}
+}
+
+func walk(node *yaml.Node, errs *[]Error) {
+	if isCustomTag(node.Tag) {
+		switch {
+		case !haTags[node.Tag]:
+			*errs = append(*errs, nodeError(node, fmt.Sprintf("unknown tag %s", node.Tag)))
+		case node.Kind != yaml.ScalarNode:
+			*errs = append(*errs, nodeError(node, fmt.Sprintf("%s must be applied to a value", node.Tag)))
+		case strings.TrimSpace(node.Value) == "" && node.Tag != "!input":
+			*errs = append(*errs, nodeError(node, fmt.Sprintf("%s is missing its value", node.Tag)))
+		}
+	}
+
+	if node.Kind == yaml.MappingNode {
+		keys := map[string]*yaml.Node{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if key.Kind != yaml.ScalarNode || key.Value == "<<" {
+				continue
+			}
+
+			if first, ok := keys[key.Value]; ok {
+				*errs = append(*errs, nodeError(key, fmt.Sprintf("duplicate key %q, first defined in line %d", key.Value, first.Line)))
+				continue
+			}
+			keys[key.Value] = key
+		}
+	}
+
+	for _, child := range node.Content {
+		walk(child, errs)
+	}
+}
+
+// Tags starting with a single ! are defined by the application, !! tags belong to the yaml spec
+func isCustomTag(tag string) bool {
+	return strings.HasPrefix(tag, "!") && !strings.HasPrefix(tag, "!!")
+}
+
+func nodeError(node *yaml.Node, message string) Error {
+	return Error{Line: node.Line, Column: node.Column, Message: message}
+}
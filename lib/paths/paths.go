@@ -0,0 +1,85 @@
+package paths
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Maximum number of symlinks followed while resolving a path, the same limit used by linux
+const maxSymlinks = 40
+
+// Returned when resolving a path follows more symlinks than linux does, usually because of a loop
+var ErrTooManySymlinks = errors.New("too many symlinks")
+
+// Follows the symlinks of an absolute path one component at a time, so symlinks that point to missing files are
+// resolved too and a dangling symlink can't hide where the path really leads. Components that don't exist are appended
+// as they are
+func Resolve(path string) (string, error) {
+	path = filepath.Clean(path)
+	resolved := filepath.VolumeName(path) + string(filepath.Separator)
+	remaining := splitPath(path)
+	links := 0
+
+	for len(remaining) > 0 {
+		component := remaining[0]
+		remaining = remaining[1:]
+
+		if component == ".." {
+			resolved = filepath.Dir(resolved)
+			continue
+		}
+
+		next := filepath.Join(resolved, component)
+		info, err := os.Lstat(next)
+		if err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+
+		links++
+		if links > maxSymlinks {
+			return "", fmt.Errorf("%w in %q", ErrTooManySymlinks, path)
+		}
+
+		target, err := os.Readlink(next)
+		if err != nil {
+			return "", err
+		}
+
+		if filepath.IsAbs(target) {
+			resolved = filepath.VolumeName(target) + string(filepath.Separator)
+		}
+		remaining = append(splitPath(target), remaining...)
+	}
+
+	return resolved, nil
+}
+
+// Checks if the path is the root or one of its descendants, both paths must be clean
+func IsWithin(root string, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Returns the components of a path without resolving the . or .. components
+func splitPath(path string) []string {
+	var components []string
+	for _, component := range strings.Split(filepath.ToSlash(path), "/") {
+		if component != "" && component != "." {
+			components = append(components, component)
+		}
+	}
+
+	return components
+}
@@ -1,26 +1,222 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"io"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/aacuadras/ha-utils/lib/auth"
+	"github.com/aacuadras/ha-utils/lib/certs"
+	"github.com/aacuadras/ha-utils/lib/config"
+	"github.com/aacuadras/ha-utils/lib/docker"
 	"github.com/aacuadras/ha-utils/server"
 	pb "github.com/aacuadras/ha-utils/server/pb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/grpclog"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
-// Start the grpc server on port 8080
+// Version of the server, it's set when building a release with -ldflags "-X main.version=..."
+var version string
+
+// Start the grpc server on the configured address, localhost:8080 by default
 func main() {
-	listener, err := net.Listen("tcp", "localhost:8080")
+	flags := config.NewFlags(flag.CommandLine)
+	generateCerts := flag.String("generate-certs", "", "Directory where a local CA, a server certificate and a client certificate are generated before exiting")
+	certHosts := flag.String("cert-hosts", "localhost,127.0.0.1", "Comma separated hosts the generated server certificate is valid for")
+	flag.Parse()
+
+	if *generateCerts != "" {
+		bundle, err := certs.GenerateLocalCA(*generateCerts, strings.Split(*certHosts, ","))
+		if err != nil {
+			log.Fatalf("Failed to generate the certificates: %v", err)
+		}
+
+		log.Printf("Generated the CA %s, the server certificate %s and the client certificate %s", bundle.CACert, bundle.ServerCert, bundle.ClientCert)
+		return
+	}
+
+	cfg, err := flags.Load(os.Getenv)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		log.Fatalf("Failed to load the configuration: %v", err)
+	}
+
+	// Errors that stop the server are always logged, even when the other logs are discarded
+	fatal := log.New(os.Stderr, "", log.LstdFlags)
+	setupLogs(cfg)
+
+	// Calls are tracked before they are authorized, so they are all waited for when the server stops
+	shutdown := server.NewShutdown()
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(shutdown.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(shutdown.StreamInterceptor()),
+	}
+
+	if cfg.TLS.Cert != "" {
+		var reloaderOpts []certs.Option
+		if cfg.TLS.ClientCA != "" {
+			reloaderOpts = append(reloaderOpts, certs.WithClientCA(cfg.TLS.ClientCA))
+		}
+
+		reloader, err := certs.NewReloader(cfg.TLS.Cert, cfg.TLS.Key, reloaderOpts...)
+		if err != nil {
+			fatal.Fatalf("Failed to load the TLS certificates: %v", err)
+		}
+
+		go reloader.Watch(context.Background())
+		opts = append(opts, grpc.Creds(credentials.NewTLS(reloader.ServerConfig())))
+	}
+
+	if cfg.Auth.Policy != "" {
+		policy, err := auth.LoadPolicy(cfg.Auth.Policy)
+		if err != nil {
+			fatal.Fatalf("Failed to load the auth policy: %v", err)
+		}
+
+		if cfg.TLS.Cert == "" {
+			log.Printf("The bearer tokens are sent in plaintext, set a TLS certificate to encrypt them")
+		}
+
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(server.UnaryAuthInterceptor(policy)),
+			grpc.ChainStreamInterceptor(server.StreamAuthInterceptor(policy)),
+		)
 	}
 
-	var opts []grpc.ServerOption
 	s := grpc.NewServer(opts...)
-	pb.RegisterDockerUtilsServer(s, server.NewServer())
-	pb.RegisterFileUtilsServer(s, server.NewFileServer())
-	reflection.Register(s)
-	s.Serve(listener)
+	runtime := docker.NewEngine()
+
+	// The health and the info of the server are always served, monitoring uses them
+	var healthOpts []server.HealthOption
+	if cfg.Enabled(config.ServiceDocker) {
+		healthOpts = append(healthOpts, server.WithDockerHealth(runtime))
+	}
+	if cfg.Enabled(config.ServiceFiles) {
+		healthOpts = append(healthOpts, server.WithFilesHealth(cfg.Files.Root))
+	}
+	health := server.NewHealth(healthOpts...)
+	healthpb.RegisterHealthServer(s, health)
+	pb.RegisterServerUtilsServer(s, server.NewInfoServer(runtime,
+		server.WithVersion(version),
+		server.WithRoots(cfg.Files.Root, cfg.Containers.HostRoot),
+		server.WithServices(cfg.Services),
+	))
+
+	if cfg.Enabled(config.ServiceDocker) {
+		pb.RegisterDockerUtilsServer(s, server.NewServer(runtime,
+			server.WithHostRoot(cfg.Containers.HostRoot),
+			server.WithContainerDefaults(server.ContainerDefaults{
+				Image:         cfg.Containers.Image,
+				Tag:           cfg.Containers.Tag,
+				RestartPolicy: cfg.Containers.RestartPolicy,
+				NetworkMode:   cfg.Containers.NetworkMode,
+				Timezone:      cfg.Timezone,
+			}),
+		))
+	}
+	if cfg.Enabled(config.ServiceFiles) {
+		pb.RegisterFileUtilsServer(s, server.NewFileServer(
+			server.WithFileRoot(cfg.Files.Root),
+			server.WithBackups(cfg.Files.BackupDir, cfg.Files.BackupRetention),
+			server.WithConfigCheck(runtime, cfg.Files.CheckContainer),
+		))
+	}
+	if cfg.Enabled(config.ServiceReflection) {
+		reflection.Register(s)
+	}
+
+	listeners, err := listen(cfg.Listen)
+	if err != nil {
+		fatal.Fatalf("Failed to listen: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go health.Monitor(ctx)
+
+	errs := make(chan error, len(listeners))
+	for _, listener := range listeners {
+		log.Printf("Listening on %s", listener.Addr())
+		go func(listener net.Listener) {
+			errs <- s.Serve(listener)
+		}(listener)
+	}
+
+	select {
+	case err := <-errs:
+		fatal.Fatalf("Failed to serve: %v", err)
+	case <-ctx.Done():
+	}
+
+	// A second signal kills the server right away, the health checks report that it's not serving while it drains
+	stop()
+	health.Shutdown()
+
+	log.Printf("Shutting down, waiting up to %s for the calls in progress", cfg.ShutdownTimeout)
+	if shutdown.Stop(s, cfg.ShutdownTimeout) {
+		log.Printf("Server stopped")
+	} else {
+		log.Printf("Server stopped, the calls that didn't finish were cancelled")
+	}
+}
+
+// Sets the timezone of the logs and which logs are written, the debug level adds the logs of gRPC
+func setupLogs(cfg *config.Config) {
+	if location, err := time.LoadLocation(cfg.Timezone); err == nil {
+		time.Local = location
+	}
+
+	switch cfg.LogLevel {
+	case "debug":
+		grpclog.SetLoggerV2(grpclog.NewLoggerV2WithVerbosity(os.Stderr, os.Stderr, os.Stderr, 2))
+	case "error":
+		log.SetOutput(io.Discard)
+	}
+}
+
+// Listens on the TCP address and the unix socket of the configuration. A socket left behind by a previous run is
+// replaced, and the new one can only be used by the owner and its group
+func listen(cfg config.Listen) ([]net.Listener, error) {
+	var listeners []net.Listener
+
+	if cfg.Address != "" {
+		listener, err := net.Listen("tcp", cfg.Address)
+		if err != nil {
+			return nil, err
+		}
+		listeners = append(listeners, listener)
+	}
+
+	if cfg.Socket != "" {
+		if info, err := os.Stat(cfg.Socket); err == nil && info.Mode()&os.ModeSocket != 0 {
+			if err := os.Remove(cfg.Socket); err != nil {
+				return nil, err
+			}
+		} else if err == nil {
+			return nil, errors.New(cfg.Socket + " exists and is not a socket")
+		}
+
+		listener, err := net.Listen("unix", cfg.Socket)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := os.Chmod(cfg.Socket, 0660); err != nil {
+			return nil, err
+		}
+		listeners = append(listeners, listener)
+	}
+
+	return listeners, nil
 }
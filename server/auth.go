@@ -0,0 +1,291 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/aacuadras/ha-utils/lib/auth"
+	"github.com/aacuadras/ha-utils/lib/docker"
+	"github.com/aacuadras/ha-utils/server/pb"
+	"github.com/docker/docker/api/types/container"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Services that can be called without credentials, monitoring checks the health of the server without a principal
+var publicServices = []string{"/" + healthpb.Health_ServiceDesc.ServiceName + "/"}
+
+// Requests that manage a single container
+type containerRequest interface {
+	GetContainerName() string
+}
+
+// Requests that read or write a single file
+type fileRequest interface {
+	GetFileName() string
+}
+
+// This function returns an interceptor that authenticates the clients of unary calls and checks the policy before
+// every call, the health checks are always allowed
+func UnaryAuthInterceptor(policy *auth.Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		principal, err := authorize(ctx, policy, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := authorizeRequest(principal, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// This function returns an interceptor that authenticates the clients of streaming calls and checks the policy before
+// every call. Every message sent by the client is checked as well, so a stream can't be used to reach files or
+// containers the client is not allowed to. The health checks are always allowed
+func StreamAuthInterceptor(policy *auth.Policy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isPublic(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		principal, err := authorize(stream.Context(), policy, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authorizedStream{ServerStream: stream, principal: principal, method: info.FullMethod})
+	}
+}
+
+// Stream that checks every message received against the policy
+type authorizedStream struct {
+	grpc.ServerStream
+	principal *auth.Principal
+	method    string
+}
+
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return authorizeRequest(s.principal, s.method, m)
+}
+
+// Reports if the method belongs to a service that doesn't need credentials
+func isPublic(method string) bool {
+	for _, prefix := range publicServices {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Finds the principal of the client and checks that it can make the call. Bearer tokens take precedence over the
+// certificate of the client
+func authorize(ctx context.Context, policy *auth.Policy, method string) (*auth.Principal, error) {
+	principal, err := authenticate(ctx, policy)
+	if err != nil {
+		return nil, authError(method, err)
+	}
+
+	if err := principal.AllowRPC(method); err != nil {
+		return nil, authError(method, err)
+	}
+
+	return principal, nil
+}
+
+// Returns the principal of the bearer token in the authorization header or the one named after the common name of
+// the verified client certificate
+func authenticate(ctx context.Context, policy *auth.Policy) (*auth.Principal, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			scheme, token, found := strings.Cut(values[0], " ")
+			if !found || !strings.EqualFold(scheme, "bearer") || token == "" {
+				return nil, fmt.Errorf("%w: the authorization header must be a bearer token", auth.ErrUnauthenticated)
+			}
+
+			return policy.Authenticate(token)
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.VerifiedChains) > 0 {
+			return policy.Principal(tlsInfo.State.VerifiedChains[0][0].Subject.CommonName)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no bearer token or client certificate", auth.ErrUnauthenticated)
+}
+
+// Checks that the principal can access the file or container of the request. Requests that reach several containers,
+// such as watching or listing all of them, applying a stack or managing the images, need a principal that isn't
+// limited to some containers, like the ones that choose the image of a container since it's pulled. Containers with
+// access to the host need one that isn't limited at all
+func authorizeRequest(principal *auth.Principal, method string, req interface{}) error {
+	var err error
+	switch r := req.(type) {
+	case *pb.ApplyStackRequest:
+		err = principal.AllowEveryContainer("apply stacks")
+		if err == nil {
+			err = authorizeStack(principal, r)
+		}
+	case *pb.WatchRequest:
+		if len(r.ContainerNames) == 0 {
+			err = principal.AllowEveryContainer("watch every container")
+		}
+		for _, name := range r.ContainerNames {
+			if err == nil {
+				err = principal.AllowContainer(name)
+			}
+		}
+	case *pb.ListContainersRequest:
+		err = principal.AllowEveryContainer("list the containers")
+	case *pb.ListImagesRequest:
+		err = principal.AllowEveryContainer("list the images")
+	case *pb.PullImageRequest:
+		err = principal.AllowEveryContainer("pull images")
+	case *pb.PruneImagesRequest:
+		err = principal.AllowEveryContainer("prune images")
+	case *pb.ContainerRequest:
+		err = principal.AllowContainer(r.ContainerName)
+		if err == nil && (r.Image != "" || r.Tag != "") {
+			err = principal.AllowEveryContainer("choose the image of a container")
+		}
+		if err == nil {
+			err = authorizeHost(principal, requestSettings(r))
+		}
+	case *pb.UpgradeRequest:
+		err = principal.AllowContainer(r.ContainerName)
+		if err == nil && (r.Image != "" || r.Tag != "") {
+			err = principal.AllowEveryContainer("choose the image of a container")
+		}
+	case containerRequest:
+		err = principal.AllowContainer(r.GetContainerName())
+	case fileRequest:
+		err = principal.AllowPath(r.GetFileName())
+	}
+
+	if err != nil {
+		return authError(method, err)
+	}
+
+	return nil
+}
+
+// Checks the services of the stack, specs that can't be parsed are left to the call to reject
+func authorizeStack(principal *auth.Principal, in *pb.ApplyStackRequest) error {
+	var services []*docker.Settings
+	switch spec := in.Spec.(type) {
+	case *pb.ApplyStackRequest_Stack:
+		for _, service := range spec.Stack.GetServices() {
+			if service.Container != nil {
+				services = append(services, requestSettings(service.Container))
+			}
+		}
+	case *pb.ApplyStackRequest_Yaml:
+		stack, err := docker.ParseStack([]byte(spec.Yaml))
+		if err != nil {
+			return nil
+		}
+		for _, service := range stack.Services {
+			if service.Settings != nil {
+				services = append(services, service.Settings)
+			}
+		}
+	}
+
+	for _, settings := range services {
+		if err := authorizeHost(principal, settings); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Checks that the principal can give the container access to the host or to other containers through privileges,
+// devices, bind mounts, named volumes or the network of the host or another container
+func authorizeHost(principal *auth.Principal, settings *docker.Settings) error {
+	if settings.Privileged {
+		return principal.AllowHost("start privileged containers")
+	}
+
+	if len(settings.Devices) > 0 {
+		return principal.AllowHost("pass devices to containers")
+	}
+
+	if settings.ConfigDir != "" {
+		return principal.AllowHost("mount the config dir")
+	}
+
+	mode := container.NetworkMode(settings.NetworkMode)
+	if mode.IsHost() {
+		return principal.AllowHost("use the network of the host")
+	}
+	if mode.IsContainer() {
+		return principal.AllowHost("join the network of " + mode.ConnectedContainer())
+	}
+
+	for _, m := range settings.Mounts {
+		if m.Type == "bind" || (m.Type == "" && filepath.IsAbs(m.Source)) {
+			return principal.AllowHost("bind mount " + m.Source)
+		}
+
+		// Anonymous volumes belong to the container, named ones can be shared with any other container
+		if (m.Type == "" || m.Type == "volume") && m.Source != "" {
+			return principal.AllowHost("mount the volume " + m.Source)
+		}
+	}
+
+	return nil
+}
+
+// Returns the settings of the request that give the container access to the host
+func requestSettings(in *pb.ContainerRequest) *docker.Settings {
+	settings := &docker.Settings{
+		NetworkMode: in.NetworkMode,
+		ConfigDir:   in.ConfigDir,
+		Privileged:  in.Privileged,
+	}
+
+	for _, m := range in.Mounts {
+		settings.Mounts = append(settings.Mounts, docker.Mount{Type: m.Type, Source: m.Source})
+	}
+
+	for _, device := range in.Devices {
+		settings.Devices = append(settings.Devices, docker.Device{HostPath: device.HostPath})
+	}
+
+	return settings
+}
+
+// Converts the errors of the policy to grpc errors, denials are logged
+func authError(method string, err error) error {
+	log.Printf("Denied %s: %v", strings.TrimPrefix(method, "/"), err)
+
+	if errors.Is(err, auth.ErrUnauthenticated) {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return status.Error(codes.PermissionDenied, err.Error())
+}
@@ -2,37 +2,113 @@ package server
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/aacuadras/ha-utils/lib/docker"
 	pb "github.com/aacuadras/ha-utils/server/pb"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Readiness modes of the requests mapped to the ones used by docker
+var readinessModes = map[pb.ReadinessMode]docker.ReadinessMode{
+	pb.ReadinessMode_READINESS_AUTO:        docker.ReadinessAuto,
+	pb.ReadinessMode_READINESS_HEALTHCHECK: docker.ReadinessHealthcheck,
+	pb.ReadinessMode_READINESS_HTTP:        docker.ReadinessHTTP,
+}
+
+const (
+	defaultImage    = "homeassistant/home-assistant"
+	defaultTimezone = "America/Chicago"
+	defaultPort     = 8123
 )
 
 type server struct {
 	pb.UnimplementedDockerUtilsServer
+	runtime  docker.Runtime
+	hostRoot string
+	defaults ContainerDefaults
+}
+
+// Settings of the containers used when the requests don't set them. The image is home assistant unless another one is
+// configured, and its web port is published when the request has no ports
+type ContainerDefaults struct {
+	Image         string
+	Tag           string
+	RestartPolicy string
+	NetworkMode   string
+	Timezone      string
+}
+
+// Option used to configure the docker server
+type DockerOption func(*server)
+
+// Sets the directory of the host that containers are allowed to bind mount, bind mounts are rejected if it's not set
+func WithHostRoot(root string) DockerOption {
+	return func(s *server) {
+		s.hostRoot = root
+	}
 }
 
-// Returns the current server implementation
-func NewServer() pb.DockerUtilsServer {
-	return &server{}
+// Sets the settings used for the containers when the requests don't set them, empty values keep the defaults
+func WithContainerDefaults(defaults ContainerDefaults) DockerOption {
+	return func(s *server) {
+		if defaults.Image != "" {
+			s.defaults.Image = defaults.Image
+			s.defaults.Tag = defaults.Tag
+		}
+		if defaults.RestartPolicy != "" {
+			s.defaults.RestartPolicy = defaults.RestartPolicy
+		}
+		if defaults.NetworkMode != "" {
+			s.defaults.NetworkMode = defaults.NetworkMode
+		}
+		if defaults.Timezone != "" {
+			s.defaults.Timezone = defaults.Timezone
+		}
+	}
 }
 
-// This call starts a home assistant docker container and returns the ID and status
+// Returns the current server implementation, every container operation runs in the runtime
+func NewServer(rt docker.Runtime, opts ...DockerOption) pb.DockerUtilsServer {
+	s := &server{
+		runtime:  rt,
+		defaults: ContainerDefaults{Image: defaultImage, Timezone: defaultTimezone},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// This call starts a docker container and returns the ID and status, when the image is not specified it starts a
+// home assistant container. With a dry run it only returns the plan
 func (s *server) StartContainer(ctx context.Context, in *pb.ContainerRequest) (*pb.ContainerResponse, error) {
-	containerSettings := &docker.Settings{
-		ImageName:     "homeassistant/home-assistant",
-		ContainerName: in.ContainerName,
-		EnvVars: []string{
-			"TZ=America/Chicago",
-		},
+	containerSettings := s.containerSettings(in)
+	if err := docker.ValidateSettings(containerSettings, s.hostRoot); err != nil {
+		return nil, settingsError(err)
 	}
 
-	id, err := docker.StartContainer(containerSettings, ctx)
+	if in.DryRun {
+		plan, err := docker.PlanStartContainer(ctx, s.runtime, containerSettings)
+		if err != nil {
+			return nil, dockerError(err)
+		}
+
+		return planResponse(plan), nil
+	}
+
+	id, err := s.runtime.StartContainer(ctx, containerSettings)
 
 	if err != nil {
-		return nil, err
+		return nil, readinessError(err)
 	}
 
-	containerInfo, err := docker.GetContainer(ctx, id)
+	containerInfo, err := s.runtime.GetContainer(ctx, id)
 
 	if err != nil {
 		return nil, err
@@ -41,15 +117,20 @@ func (s *server) StartContainer(ctx context.Context, in *pb.ContainerRequest) (*
 	return &pb.ContainerResponse{Status: containerInfo.State.Status, ContainerId: id}, nil
 }
 
-// This call stops a docker container based on its name, it's not limited to home asssitant
+// This call stops a docker container based on its name, it's not limited to home asssitant. With a dry run it only
+// returns the plan
 func (s *server) StopContainer(ctx context.Context, in *pb.ContainerRequest) (*pb.ContainerResponse, error) {
-	// The only important part in the options is the container name
-	containerSettings := &docker.Settings{
-		ContainerName: in.ContainerName,
+	if in.DryRun {
+		plan, err := docker.PlanContainerAction(ctx, s.runtime, in.ContainerName, docker.StackRemove)
+		if err != nil {
+			return nil, dockerError(err)
+		}
+
+		return planResponse(plan), nil
 	}
 
-	if err := docker.StopContainer(containerSettings); err != nil {
-		return nil, err
+	if err := s.runtime.StopContainer(ctx, in.ContainerName); err != nil {
+		return nil, dockerError(err)
 	}
 
 	return &pb.ContainerResponse{ContainerId: "", Status: "stopped"}, nil
@@ -57,7 +138,7 @@ func (s *server) StopContainer(ctx context.Context, in *pb.ContainerRequest) (*p
 
 // This call returns the information of a docker container, if the container is not running, it returns an empty response
 func (s *server) GetContainer(ctx context.Context, in *pb.ContainerRequest) (*pb.ContainerResponse, error) {
-	status, err := docker.GetContainer(ctx, in.ContainerName)
+	status, err := s.runtime.GetContainer(ctx, in.ContainerName)
 	if err != nil {
 		return &pb.ContainerResponse{}, nil
 	}
@@ -67,3 +148,156 @@ func (s *server) GetContainer(ctx context.Context, in *pb.ContainerRequest) (*pb
 		Status:      status.State.Status,
 	}, nil
 }
+
+// Returns the plan of a dry run, nothing was changed so the response has no status
+func planResponse(plan docker.ContainerPlan) *pb.ContainerResponse {
+	return &pb.ContainerResponse{
+		ContainerName: plan.ContainerName,
+		Image:         plan.Image,
+		Plan: &pb.ContainerPlan{
+			ContainerName: plan.ContainerName,
+			Image:         plan.Image,
+			ImagePresent:  plan.ImagePresent,
+			CurrentImage:  plan.CurrentImage,
+			Action:        string(plan.Action),
+			Reason:        plan.Reason,
+		},
+	}
+}
+
+// Builds the settings of the container from the request. The default image is used when the request doesn't set one,
+// in which case its web port is published if no other ports are specified, and the timezone is set unless the request
+// sets it
+func (s *server) containerSettings(in *pb.ContainerRequest) *docker.Settings {
+	settings := requestContainerSettings(in)
+
+	if settings.RestartPolicy == "" {
+		settings.RestartPolicy = s.defaults.RestartPolicy
+	}
+
+	if settings.NetworkMode == "" {
+		settings.NetworkMode = s.defaults.NetworkMode
+	}
+
+	if settings.ImageName == "" {
+		settings.ImageName = s.defaults.Image
+		if settings.Tag == "" {
+			settings.Tag = s.defaults.Tag
+		}
+		if len(in.Ports) == 0 {
+			settings.Ports = append(settings.Ports, docker.PortMapping{ContainerPort: defaultPort})
+		}
+	}
+
+	if _, ok := in.Env["TZ"]; !ok {
+		settings.EnvVars = append([]string{"TZ=" + s.defaults.Timezone}, settings.EnvVars...)
+	}
+
+	return settings
+}
+
+// Converts the request to the settings of the container as they are, without any of the defaults
+func requestContainerSettings(in *pb.ContainerRequest) *docker.Settings {
+	settings := &docker.Settings{
+		ImageName:     in.Image,
+		Tag:           in.Tag,
+		ContainerName: in.ContainerName,
+		RestartPolicy: in.RestartPolicy,
+		NetworkMode:   in.NetworkMode,
+		Labels:        in.Labels,
+		ConfigDir:     in.ConfigDir,
+		Privileged:    in.Privileged,
+	}
+
+	if in.Readiness != nil {
+		settings.Readiness = &docker.Readiness{
+			Mode:    readinessModes[in.Readiness.Mode],
+			URL:     in.Readiness.Url,
+			Timeout: time.Duration(in.Readiness.TimeoutSeconds) * time.Second,
+		}
+	}
+
+	for _, port := range in.Ports {
+		settings.Ports = append(settings.Ports, docker.PortMapping{
+			HostIP:        port.HostIp,
+			HostPort:      int(port.HostPort),
+			ContainerPort: int(port.ContainerPort),
+			Protocol:      port.Protocol,
+		})
+	}
+
+	for _, mount := range in.Mounts {
+		settings.Mounts = append(settings.Mounts, docker.Mount{
+			Type:     mount.Type,
+			Source:   mount.Source,
+			Target:   mount.Target,
+			ReadOnly: mount.ReadOnly,
+		})
+	}
+
+	for _, device := range in.Devices {
+		settings.Devices = append(settings.Devices, docker.Device{
+			HostPath:      device.HostPath,
+			ContainerPath: device.ContainerPath,
+			Permissions:   device.Permissions,
+		})
+	}
+
+	settings.EnvVars = docker.EnvVars(in.Env)
+
+	return settings
+}
+
+// Converts the errors returned by docker to grpc errors, the errdefs classes of docker are found even if the error
+// was wrapped
+func dockerError(err error) error {
+	for cause := err; cause != nil; cause = errors.Unwrap(cause) {
+		switch {
+		case errdefs.IsNotFound(cause):
+			return status.Error(codes.NotFound, err.Error())
+		case errdefs.IsConflict(cause):
+			return status.Error(codes.FailedPrecondition, err.Error())
+		case errdefs.IsInvalidParameter(cause):
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	switch {
+	case client.IsErrConnectionFailed(err):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return err
+	}
+}
+
+// Converts the errors of a container that didn't become ready to grpc errors, the message has the last lines of its
+// logs. Containers that didn't become ready in time return DeadlineExceeded and the ones that exited or are unhealthy
+// return Unavailable
+func readinessError(err error) error {
+	var notReady *docker.NotReadyError
+	if !errors.As(err, &notReady) {
+		return dockerError(err)
+	}
+
+	if notReady.TimedOut {
+		return status.Error(codes.DeadlineExceeded, notReady.Error())
+	}
+
+	return status.Error(codes.Unavailable, notReady.Error())
+}
+
+// Converts the errors returned when validating the settings of a container to grpc errors
+func settingsError(err error) error {
+	switch {
+	case errors.Is(err, docker.ErrPathNotAllowed):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, docker.ErrInvalidSettings):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"errors"
+
+	"github.com/aacuadras/ha-utils/lib/docker"
+	pb "github.com/aacuadras/ha-utils/server/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Event types of the requests mapped to the ones used by docker
+var eventTypes = map[pb.ContainerEventType]docker.EventType{
+	pb.ContainerEventType_EVENT_START:         docker.EventStart,
+	pb.ContainerEventType_EVENT_DIE:           docker.EventDie,
+	pb.ContainerEventType_EVENT_OOM:           docker.EventOOM,
+	pb.ContainerEventType_EVENT_HEALTH_STATUS: docker.EventHealthStatus,
+	pb.ContainerEventType_EVENT_RESTART:       docker.EventRestart,
+}
+
+// This call streams the lifecycle events of the containers that match the filters as they happen, such as a container
+// dying or becoming unhealthy. The stream stays open until the client cancels it
+func (s *server) WatchContainers(in *pb.WatchRequest, stream pb.DockerUtils_WatchContainersServer) error {
+	options := docker.WatchOptions{
+		Names:  in.ContainerNames,
+		Labels: in.Labels,
+	}
+
+	for _, event := range in.Events {
+		eventType, ok := eventTypes[event]
+		if !ok {
+			return status.Errorf(codes.InvalidArgument, "unknown event type %v", event)
+		}
+		options.Types = append(options.Types, eventType)
+	}
+
+	err := s.runtime.WatchContainers(stream.Context(), options, func(event docker.ContainerEvent) error {
+		return stream.Send(&pb.ContainerEvent{
+			Type:          eventType(event.Type),
+			ContainerId:   event.ContainerID,
+			ContainerName: event.ContainerName,
+			Image:         event.Image,
+			ExitCode:      int32(event.ExitCode),
+			HealthStatus:  event.HealthStatus,
+			Time:          timestamppb.New(event.Time),
+		})
+	})
+	if err != nil {
+		// The client cancelling the stream is the expected way to stop it
+		if stream.Context().Err() != nil {
+			return nil
+		}
+
+		if errors.Is(err, docker.ErrInvalidFilter) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		return dockerError(err)
+	}
+
+	return nil
+}
+
+// Returns the event type of the response, events that are not in the requests are unspecified
+func eventType(event docker.EventType) pb.ContainerEventType {
+	for eventType, dockerEvent := range eventTypes {
+		if dockerEvent == event {
+			return eventType
+		}
+	}
+
+	return pb.ContainerEventType_EVENT_UNSPECIFIED
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"github.com/aacuadras/ha-utils/lib/docker"
+	pb "github.com/aacuadras/ha-utils/server/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Writer that sends everything written to it as output of one of the streams of a container
+type outputWriter struct {
+	stream pb.OutputStream
+	send   func(pb.OutputStream, []byte) error
+}
+
+func (w *outputWriter) Write(p []byte) (int, error) {
+	data := make([]byte, len(p))
+	copy(data, p)
+
+	if err := w.send(w.stream, data); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// This call runs a command inside a running container, the stdout and stderr of the command are streamed as they are
+// produced and the last message has the exit code of the command
+func (s *server) ExecContainer(in *pb.ExecRequest, stream pb.DockerUtils_ExecContainerServer) error {
+	if in.ContainerName == "" || len(in.Command) == 0 {
+		return status.Error(codes.InvalidArgument, "the container name and the command are required")
+	}
+
+	options := docker.ExecOptions{
+		Cmd:        in.Command,
+		Env:        docker.EnvVars(in.Env),
+		WorkingDir: in.WorkingDir,
+		User:       in.User,
+		Tty:        in.Tty,
+	}
+
+	send := func(output pb.OutputStream, data []byte) error {
+		return stream.Send(&pb.ExecOutput{Stream: output, Data: data})
+	}
+	stdout := &outputWriter{stream: pb.OutputStream_STDOUT, send: send}
+	stderr := &outputWriter{stream: pb.OutputStream_STDERR, send: send}
+
+	exitCode, err := s.runtime.Exec(stream.Context(), in.ContainerName, options, stdout, stderr)
+	if err != nil {
+		return dockerError(err)
+	}
+
+	return stream.Send(&pb.ExecOutput{Exited: true, ExitCode: int32(exitCode)})
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+
+	"github.com/aacuadras/ha-utils/lib/docker"
+	pb "github.com/aacuadras/ha-utils/server/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// This call pulls an image streaming the progress of every layer, the stream ends once the image is pulled. When the
+// tag is not specified the latest one is pulled, and it can't be set if the image already has a tag or digest
+func (s *server) PullImage(in *pb.PullImageRequest, stream pb.DockerUtils_PullImageServer) error {
+	if in.Image == "" {
+		return status.Error(codes.InvalidArgument, "the image is required")
+	}
+
+	image, err := docker.TaggedImage(in.Image, in.Tag)
+	if err != nil {
+		return dockerError(err)
+	}
+
+	err = s.runtime.PullImage(stream.Context(), image, func(progress docker.PullProgress) error {
+		return stream.Send(&pb.PullProgress{
+			Layer:   progress.Layer,
+			Status:  progress.Status,
+			Current: progress.Current,
+			Total:   progress.Total,
+		})
+	})
+	if err != nil {
+		return dockerError(err)
+	}
+
+	return nil
+}
+
+// This call lists the images in the machine from newest to oldest, optionally only the ones of a repository such as
+// homeassistant/home-assistant
+func (s *server) ListImages(ctx context.Context, in *pb.ListImagesRequest) (*pb.ImageList, error) {
+	images, err := s.runtime.ListImages(ctx)
+	if err != nil {
+		return nil, dockerError(err)
+	}
+
+	list := &pb.ImageList{}
+	for _, image := range images {
+		if in.Repository != "" && !hasRepository(image, in.Repository) {
+			continue
+		}
+
+		list.Images = append(list.Images, imageInfo(image))
+	}
+
+	return list, nil
+}
+
+// This call removes the old images of every repository, or only the ones in the request, keeping the newest ones so
+// there are images to roll back to. Two images are kept per repository unless the request sets it, and the images
+// used by containers are never removed. With a dry run it only returns the images that would be removed
+func (s *server) PruneImages(ctx context.Context, in *pb.PruneImagesRequest) (*pb.PruneImagesResponse, error) {
+	result, err := docker.PruneImages(ctx, s.runtime, docker.PruneOptions{
+		KeepLast:     int(in.KeepLast),
+		Repositories: in.Repositories,
+		DryRun:       in.DryRun,
+	})
+	if err != nil {
+		return nil, dockerError(err)
+	}
+
+	response := &pb.PruneImagesResponse{SpaceReclaimed: result.SpaceReclaimed, DryRun: in.DryRun}
+	for _, image := range result.Removed {
+		response.Removed = append(response.Removed, imageInfo(image))
+	}
+
+	return response, nil
+}
+
+// Checks if the image belongs to the repository
+func hasRepository(image docker.ImageSummary, repository string) bool {
+	for _, r := range image.Repositories() {
+		if r == repository {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Converts the summary of an image to the one of the responses
+func imageInfo(image docker.ImageSummary) *pb.ImageInfo {
+	return &pb.ImageInfo{
+		Id:         image.ID,
+		Tags:       image.Tags,
+		Digests:    image.Digests,
+		CreatedAt:  timestamppb.New(image.Created),
+		Size:       image.Size,
+		Containers: image.Containers,
+	}
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aacuadras/ha-utils/lib/docker"
+	pb "github.com/aacuadras/ha-utils/server/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// This call lists every container in the machine, running or not, that matches the filters of the request. It's not
+// limited to the containers started by this server
+func (s *server) ListContainers(ctx context.Context, in *pb.ListContainersRequest) (*pb.ContainerList, error) {
+	containers, err := s.runtime.ListContainers(ctx, docker.ListOptions{
+		Labels: in.Labels,
+		Name:   in.NamePattern,
+		States: in.States,
+	})
+	if err != nil {
+		if errors.Is(err, docker.ErrInvalidFilter) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		return nil, dockerError(err)
+	}
+
+	list := &pb.ContainerList{}
+	for _, container := range containers {
+		info := &pb.ContainerInfo{
+			ContainerId:   container.ID,
+			ContainerName: container.Name,
+			Image:         container.Image,
+			State:         container.State,
+			Status:        container.Status,
+			CreatedAt:     timestamppb.New(container.Created),
+			Labels:        container.Labels,
+		}
+
+		for _, port := range container.Ports {
+			info.Ports = append(info.Ports, &pb.PortMapping{
+				HostIp:        port.HostIP,
+				HostPort:      int32(port.HostPort),
+				ContainerPort: int32(port.ContainerPort),
+				Protocol:      port.Protocol,
+			})
+		}
+
+		list.Containers = append(list.Containers, info)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"github.com/aacuadras/ha-utils/lib/docker"
+	pb "github.com/aacuadras/ha-utils/server/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// This call streams the logs of a container split in stdout and stderr. When following the logs, the stream stays
+// open until the client cancels it or the container stops
+func (s *server) StreamLogs(in *pb.LogsRequest, stream pb.DockerUtils_StreamLogsServer) error {
+	if in.ContainerName == "" {
+		return status.Error(codes.InvalidArgument, "the container name is required")
+	}
+
+	options := docker.LogOptions{
+		Follow:     in.Follow,
+		Since:      in.Since,
+		Until:      in.Until,
+		Tail:       int(in.Tail),
+		Timestamps: in.Timestamps,
+		Stdout:     in.Stdout,
+		Stderr:     in.Stderr,
+	}
+
+	send := func(output pb.OutputStream, data []byte) error {
+		return stream.Send(&pb.LogOutput{Stream: output, Data: data})
+	}
+	stdout := &outputWriter{stream: pb.OutputStream_STDOUT, send: send}
+	stderr := &outputWriter{stream: pb.OutputStream_STDERR, send: send}
+
+	if err := s.runtime.Logs(stream.Context(), in.ContainerName, options, stdout, stderr); err != nil {
+		// The client cancelling a followed stream is the expected way to stop it
+		if stream.Context().Err() != nil {
+			return nil
+		}
+
+		return dockerError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"context"
+
+	"github.com/aacuadras/ha-utils/lib/docker"
+	pb "github.com/aacuadras/ha-utils/server/pb"
+)
+
+// Backends of the runtime mapped to the ones of the responses
+var runtimeBackends = map[docker.Backend]pb.RuntimeBackend{
+	docker.BackendDocker: pb.RuntimeBackend_BACKEND_DOCKER,
+	docker.BackendPodman: pb.RuntimeBackend_BACKEND_PODMAN,
+}
+
+// This call returns which container engine runs the containers, docker or podman, along with its version and address
+func (s *server) GetRuntimeInfo(ctx context.Context, in *pb.RuntimeInfoRequest) (*pb.RuntimeInfo, error) {
+	info, err := s.runtime.Info(ctx)
+	if err != nil {
+		return nil, dockerError(err)
+	}
+
+	return &pb.RuntimeInfo{
+		Backend:    runtimeBackends[info.Backend],
+		Version:    info.Version,
+		ApiVersion: info.APIVersion,
+		Host:       info.Host,
+		Rootless:   info.Rootless,
+	}, nil
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"errors"
+
+	"github.com/aacuadras/ha-utils/lib/docker"
+	pb "github.com/aacuadras/ha-utils/server/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Stack actions mapped to the ones of the responses
+var stackActions = map[docker.StackAction]pb.StackAction{
+	docker.StackUnchanged: pb.StackAction_STACK_UNCHANGED,
+	docker.StackCreate:    pb.StackAction_STACK_CREATE,
+	docker.StackRecreate:  pb.StackAction_STACK_RECREATE,
+	docker.StackRemove:    pb.StackAction_STACK_REMOVE,
+}
+
+// This call converges the containers, networks and volumes of a stack to the spec of the request, which is either a
+// proto message or a YAML file. The first message of the stream is the plan with every step, followed by the result
+// of each step as it's applied. With a dry run only the plan is sent and nothing changes
+func (s *server) ApplyStack(in *pb.ApplyStackRequest, stream pb.DockerUtils_ApplyStackServer) error {
+	var stack *docker.Stack
+	switch spec := in.Spec.(type) {
+	case *pb.ApplyStackRequest_Stack:
+		stack = stackSpec(spec.Stack)
+	case *pb.ApplyStackRequest_Yaml:
+		var err error
+		stack, err = docker.ParseStack([]byte(spec.Yaml))
+		if err != nil {
+			return stackError(err)
+		}
+	default:
+		return status.Error(codes.InvalidArgument, "the stack spec is required")
+	}
+
+	if err := docker.ValidateStack(stack, s.hostRoot); err != nil {
+		return stackError(err)
+	}
+
+	ctx := stream.Context()
+	plan, err := docker.PlanStack(ctx, s.runtime, stack)
+	if err != nil {
+		return stackError(err)
+	}
+
+	if err := stream.Send(&pb.StackUpdate{Update: &pb.StackUpdate_Plan{Plan: stackPlan(plan)}}); err != nil {
+		return err
+	}
+
+	if in.DryRun {
+		return nil
+	}
+
+	err = docker.ApplyStack(ctx, s.runtime, stack, plan, func(result docker.StepResult) error {
+		stepResult := &pb.StackStepResult{
+			Step:        stackStep(result.Step),
+			ContainerId: result.ContainerID,
+		}
+		if result.Err != nil {
+			stepResult.Error = result.Err.Error()
+		}
+
+		return stream.Send(&pb.StackUpdate{Update: &pb.StackUpdate_Result{Result: stepResult}})
+	})
+	if err != nil {
+		return readinessError(err)
+	}
+
+	return nil
+}
+
+// Builds the stack from the request. Like the services of a YAML stack, the services don't get the defaults of the
+// containers started on their own, so every service sets its image
+func stackSpec(in *pb.Stack) *docker.Stack {
+	stack := &docker.Stack{
+		Name:     in.Name,
+		Networks: in.Networks,
+		Volumes:  in.Volumes,
+	}
+
+	for _, service := range in.Services {
+		container := service.Container
+		if container == nil {
+			container = &pb.ContainerRequest{}
+		}
+
+		stack.Services = append(stack.Services, docker.Service{
+			Name:     service.Name,
+			Settings: requestContainerSettings(container),
+		})
+	}
+
+	return stack
+}
+
+func stackPlan(plan docker.Plan) *pb.StackPlan {
+	out := &pb.StackPlan{Stack: plan.Stack}
+	for _, step := range plan.Steps {
+		out.Steps = append(out.Steps, stackStep(step))
+	}
+
+	return out
+}
+
+func stackStep(step docker.PlanStep) *pb.StackStep {
+	return &pb.StackStep{
+		Kind:   string(step.Kind),
+		Name:   step.Name,
+		Action: stackActions[step.Action],
+		Reason: step.Reason,
+	}
+}
+
+// Converts the errors returned when validating or planning a stack to grpc errors
+func stackError(err error) error {
+	switch {
+	case errors.Is(err, docker.ErrInvalidStack):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, docker.ErrStackConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, docker.ErrInvalidSettings), errors.Is(err, docker.ErrPathNotAllowed):
+		return settingsError(err)
+	default:
+		return dockerError(err)
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+
+	"github.com/aacuadras/ha-utils/lib/docker"
+	pb "github.com/aacuadras/ha-utils/server/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// This call returns a single sample of the CPU, memory, network and block I/O usage of a container
+func (s *server) GetContainerStats(ctx context.Context, in *pb.StatsRequest) (*pb.ContainerStats, error) {
+	if in.ContainerName == "" {
+		return nil, status.Error(codes.InvalidArgument, "the container name is required")
+	}
+
+	stats, err := docker.GetStats(ctx, s.runtime, in.ContainerName)
+	if err != nil {
+		return nil, dockerError(err)
+	}
+
+	return containerStats(stats), nil
+}
+
+// This call streams the resource usage of a container about once a second, the stream stays open until the client
+// cancels it or the container is removed
+func (s *server) StreamContainerStats(in *pb.StatsRequest, stream pb.DockerUtils_StreamContainerStatsServer) error {
+	if in.ContainerName == "" {
+		return status.Error(codes.InvalidArgument, "the container name is required")
+	}
+
+	err := s.runtime.Stats(stream.Context(), in.ContainerName, true, func(stats docker.Stats) error {
+		return stream.Send(containerStats(stats))
+	})
+	if err != nil {
+		// The client cancelling the stream is the expected way to stop it
+		if stream.Context().Err() != nil {
+			return nil
+		}
+
+		return dockerError(err)
+	}
+
+	return nil
+}
+
+func containerStats(stats docker.Stats) *pb.ContainerStats {
+	return &pb.ContainerStats{
+		ContainerId:   stats.ContainerID,
+		ContainerName: stats.ContainerName,
+		Time:          timestamppb.New(stats.Time),
+		CpuPercent:    stats.CPUPercent,
+		MemoryUsage:   stats.MemoryUsage,
+		MemoryLimit:   stats.MemoryLimit,
+		MemoryPercent: stats.MemoryPercent,
+		NetworkRx:     stats.NetworkRx,
+		NetworkTx:     stats.NetworkTx,
+		BlockRead:     stats.BlockRead,
+		BlockWrite:    stats.BlockWrite,
+		Pids:          stats.PIDs,
+	}
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aacuadras/ha-utils/lib/docker"
+	pb "github.com/aacuadras/ha-utils/server/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// This call restarts a docker container based on its name, unlike stopping it the container and its volumes are kept.
+// With a dry run it only returns the plan
+func (s *server) RestartContainer(ctx context.Context, in *pb.ContainerRequest) (*pb.ContainerResponse, error) {
+	if in.ContainerName == "" {
+		return nil, status.Error(codes.InvalidArgument, "the container name is required")
+	}
+
+	if in.DryRun {
+		plan, err := docker.PlanContainerAction(ctx, s.runtime, in.ContainerName, docker.StackRestart)
+		if err != nil {
+			return nil, dockerError(err)
+		}
+
+		return planResponse(plan), nil
+	}
+
+	if err := s.runtime.RestartContainer(ctx, in.ContainerName); err != nil {
+		return nil, dockerError(err)
+	}
+
+	return s.containerResponse(ctx, in.ContainerName)
+}
+
+// This call recreates a docker container with a newer image keeping its settings and volumes. When the image is not
+// specified the current image is used, so setting only the tag moves the container to another version. If the new
+// container doesn't become ready within the timeout, the previous container is restored. With a dry run it only returns
+// the plan
+func (s *server) UpgradeContainer(ctx context.Context, in *pb.UpgradeRequest) (*pb.ContainerResponse, error) {
+	if in.ContainerName == "" {
+		return nil, status.Error(codes.InvalidArgument, "the container name is required")
+	}
+
+	options := docker.UpgradeOptions{
+		Image:         in.Image,
+		Tag:           in.Tag,
+		HealthTimeout: time.Duration(in.HealthTimeoutSeconds) * time.Second,
+	}
+
+	if in.DryRun {
+		plan, err := docker.PlanUpgradeContainer(ctx, s.runtime, in.ContainerName, options)
+		if err != nil {
+			return nil, dockerError(err)
+		}
+
+		return planResponse(plan), nil
+	}
+
+	id, err := s.runtime.UpgradeContainer(ctx, in.ContainerName, options)
+	if err != nil {
+		if errors.Is(err, docker.ErrUpgradeFailed) {
+			// The previous container is running again, so the client can retry the upgrade
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+
+		return nil, dockerError(err)
+	}
+
+	return s.containerResponse(ctx, id)
+}
+
+// Returns the current state of a container
+func (s *server) containerResponse(ctx context.Context, containerName string) (*pb.ContainerResponse, error) {
+	info, err := s.runtime.GetContainer(ctx, containerName)
+	if err != nil {
+		return nil, dockerError(err)
+	}
+
+	return &pb.ContainerResponse{
+		Status:        info.State.Status,
+		ContainerId:   info.ID,
+		ContainerName: strings.TrimPrefix(info.Name, "/"),
+		Image:         info.Config.Image,
+	}, nil
+}
@@ -2,57 +2,103 @@ package server
 
 import (
 	"context"
+	"errors"
 	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
 	"sync"
 
+	"github.com/aacuadras/ha-utils/lib/docker"
 	"github.com/aacuadras/ha-utils/lib/filediff"
+	"github.com/aacuadras/ha-utils/lib/haconfig"
 	"github.com/aacuadras/ha-utils/server/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Number of revisions kept for every file unless another retention is configured
+const defaultBackupRetention = 10
+
 type fileServer struct {
 	pb.UnimplementedFileUtilsServer
-	mu             sync.Mutex
-	fileDiffs      []*pb.FileDiff
-	processedFiles []*pb.ProcessedFile
+	mu              sync.Mutex
+	root            *filediff.Root
+	rootDir         string
+	backupDir       string
+	backupRetention int
+	checkContainer  string
+	runtime         docker.Runtime
+	fileDiffs       []*pb.FileDiff
+	processedFiles  []*pb.ProcessedFile
+}
+
+// Option used to configure the file server
+type FileOption func(*fileServer)
+
+// Sets the directory that every file name is resolved against, files outside of it can't be read or written
+func WithFileRoot(dir string) FileOption {
+	return func(s *fileServer) {
+		s.rootDir = dir
+	}
 }
 
-func NewFileServer() pb.FileUtilsServer {
-	return &fileServer{}
+// Sets the directory where the previous versions of the files are stored and how many versions are kept for each
+// file, a retention of zero or less keeps all of them
+func WithBackups(dir string, retention int) FileOption {
+	return func(s *fileServer) {
+		s.backupDir = dir
+		s.backupRetention = retention
+	}
+}
+
+// Sets the home assistant container used to check the whole configuration after the files that must be validated
+// are written and the runtime where it runs, the files are restored if the check fails
+func WithConfigCheck(rt docker.Runtime, containerName string) FileOption {
+	return func(s *fileServer) {
+		s.runtime = rt
+		s.checkContainer = containerName
+	}
+}
+
+// Returns the file server implementation, the current working directory is used as the file root unless another
+// one is configured. Backups are stored in .ha-utils/backups inside the root by default
+func NewFileServer(opts ...FileOption) pb.FileUtilsServer {
+	s := &fileServer{
+		rootDir:         ".",
+		backupRetention: defaultBackupRetention,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.root = filediff.NewRoot(s.rootDir)
+	if s.backupDir == "" {
+		s.backupDir = filepath.Join(s.root.Dir(), ".ha-utils", "backups")
+	}
+	s.root.SetBackupStore(filediff.NewBackupStore(s.backupDir, s.backupRetention))
+
+	return s
 }
 
 // This function receives the encoded contents of a configuration file and the desired path that the file should
 // be in, if the contents of the files are the same as the ones currently in the path, then it will inform the client
-// that the file was not processed and ignore it
+// that the file was not processed and ignore it. Missing files and their directories are created unless the mode of
+// the file only allows replacing it
 func (s *fileServer) SendFile(ctx context.Context, in *pb.File) (*pb.ProcessedFile, error) {
-	// Only substitute the file if it's not equal
-	isEqual, err := filediff.IsSameFile(in.FileName, in.EncodedContent)
+	processed, err := s.processFile(ctx, in)
 	if err != nil {
 		return &pb.ProcessedFile{}, err
 	}
 
-	if !isEqual {
-		if err := filediff.ReplaceFile(in.FileName, in.EncodedContent); err != nil {
-			return &pb.ProcessedFile{
-				Processed: false,
-				Error:     err.Error(),
-			}, nil
-		}
-
-		return &pb.ProcessedFile{
-			Processed: true,
-			FileName:  in.FileName,
-		}, nil
-	}
-
-	return &pb.ProcessedFile{
-		Processed: false,
-	}, nil
+	return processed, nil
 }
 
 // This function works the same way as SendFile, but it receives a stream of File so it can process multiple files
-// instead of one at a time
+// instead of one at a time. If the first file is transactional, the whole stream is applied as a single transaction
 func (s *fileServer) SendFiles(stream pb.FileUtils_SendFilesServer) error {
-	for {
+	for first := true; ; first = false {
 		in, err := stream.Recv()
 		if err == io.EOF {
 			return nil
@@ -61,30 +107,17 @@ func (s *fileServer) SendFiles(stream pb.FileUtils_SendFilesServer) error {
 			return err
 		}
 
-		isEqual, err := filediff.IsSameFile(in.FileName, in.EncodedContent)
+		if first && in.Transactional {
+			return s.sendFilesTransaction(stream, in)
+		}
+
+		processed, err := s.processFile(stream.Context(), in)
 		if err != nil {
 			return err
 		}
 
 		s.mu.Lock()
-		if !isEqual {
-			if err := filediff.ReplaceFile(in.FileName, in.EncodedContent); err != nil {
-				s.processedFiles = append(s.processedFiles, &pb.ProcessedFile{
-					Processed: false,
-					Error:     err.Error(),
-				})
-			} else {
-				s.processedFiles = append(s.processedFiles, &pb.ProcessedFile{
-					Processed: true,
-					FileName:  in.FileName,
-				})
-			}
-		} else {
-			s.processedFiles = append(s.processedFiles, &pb.ProcessedFile{
-				Processed: false,
-			})
-		}
-
+		s.processedFiles = append(s.processedFiles, processed)
 		rn := make([]*pb.ProcessedFile, len(s.processedFiles))
 		copy(rn, s.processedFiles)
 		s.mu.Unlock()
@@ -98,15 +131,188 @@ func (s *fileServer) SendFiles(stream pb.FileUtils_SendFilesServer) error {
 	}
 }
 
+// Stages every file of the stream and commits them all once the client closes it. If any file fails, none of them
+// are written and every file is reported as rolled back, the file that failed carries the cause. Every file of the
+// stream belongs to the transaction whether it's marked as transactional or not, and if any of them is a dry run the
+// whole transaction is only planned, so nothing is written
+func (s *fileServer) sendFilesTransaction(stream pb.FileUtils_SendFilesServer, first *pb.File) error {
+	tx := s.root.Begin()
+	files := []*pb.File{}
+
+	in := first
+	dryRun := false
+	for {
+		dryRun = dryRun || in.DryRun
+
+		// Staging errors are kept by the transaction and reported once the stream ends
+		files = append(files, in)
+		tx.Stage(in.FileName, in.EncodedContent, writeMode(in.Mode), s.checks(in)...)
+		if in.Validate && s.checkContainer != "" {
+			tx.Verify(s.checkConfig(stream.Context()))
+		}
+
+		next, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		in = next
+	}
+
+	if dryRun {
+		return s.planTransaction(stream, tx, files)
+	}
+
+	actions, err := tx.Commit()
+	for i, in := range files {
+		processed := &pb.ProcessedFile{
+			FileName:    in.FileName,
+			Transaction: pb.TransactionStatus_COMMITTED,
+		}
+
+		if err != nil {
+			processed.Transaction = pb.TransactionStatus_ROLLED_BACK
+			processed.Error = "transaction rolled back: " + err.Error()
+
+			var txErr *filediff.TransactionError
+			if errors.As(err, &txErr) {
+				if !txErr.RolledBack() {
+					processed.Transaction = pb.TransactionStatus_ROLLBACK_FAILED
+					processed.Error = "transaction failed and couldn't be rolled back: " + err.Error()
+				} else if txErr.Index == i {
+					processed.Error = txErr.Err.Error()
+				}
+			}
+		} else {
+			processed.Processed = actions[i] != filediff.Unchanged
+			processed.Action = fileAction(actions[i])
+		}
+
+		if err := stream.Send(processed); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reports what committing the transaction would do to each file and discards it. The transaction status is the one
+// the commit would have, although the config check only runs when the files are written
+func (s *fileServer) planTransaction(stream pb.FileUtils_SendFilesServer, tx *filediff.Transaction, files []*pb.File) error {
+	plans, err := tx.Plan()
+	tx.Rollback()
+
+	for i, in := range files {
+		processed := &pb.ProcessedFile{
+			FileName:    in.FileName,
+			Transaction: pb.TransactionStatus_COMMITTED,
+			DryRun:      true,
+		}
+
+		if err != nil {
+			processed.Transaction = pb.TransactionStatus_ROLLED_BACK
+			processed.Error = "transaction rolled back: " + err.Error()
+
+			var txErr *filediff.TransactionError
+			if errors.As(err, &txErr) && txErr.Index == i {
+				processed.Error = txErr.Err.Error()
+			}
+		} else {
+			processed = plannedFile(in.FileName, plans[i])
+			processed.Transaction = pb.TransactionStatus_COMMITTED
+		}
+
+		if err := stream.Send(processed); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Writes a single file following its mode. Files that can't be resolved or decoded are returned as grpc errors, while
+// files that can't be written or fail their validation are reported in the processed file. With a dry run nothing is
+// written and the processed file has what would happen with the diff
+func (s *fileServer) processFile(ctx context.Context, in *pb.File) (*pb.ProcessedFile, error) {
+	if in.DryRun {
+		return s.planFile(in)
+	}
+
+	var action filediff.Action
+	var err error
+	if in.Validate && s.checkContainer != "" {
+		action, err = s.writeCheckedFile(ctx, in)
+	} else {
+		action, err = s.root.WriteFile(in.FileName, in.EncodedContent, writeMode(in.Mode), s.checks(in)...)
+	}
+
+	if err != nil {
+		if isRequestError(err) {
+			return nil, fileError(err)
+		}
+
+		return &pb.ProcessedFile{
+			Processed: false,
+			FileName:  in.FileName,
+			Error:     err.Error(),
+		}, nil
+	}
+
+	if action == filediff.Unchanged {
+		return &pb.ProcessedFile{
+			Processed: false,
+			FileName:  in.FileName,
+			Action:    pb.FileAction_UNCHANGED,
+		}, nil
+	}
+
+	return &pb.ProcessedFile{
+		Processed: true,
+		FileName:  in.FileName,
+		Action:    fileAction(action),
+	}, nil
+}
+
+// Returns what writing a single file would do, the errors are reported the same way as writing it
+func (s *fileServer) planFile(in *pb.File) (*pb.ProcessedFile, error) {
+	plan, err := s.root.PlanFile(in.FileName, in.EncodedContent, writeMode(in.Mode), s.checks(in)...)
+	if err != nil {
+		if isRequestError(err) {
+			return nil, fileError(err)
+		}
+
+		return &pb.ProcessedFile{
+			FileName: in.FileName,
+			Error:    err.Error(),
+			DryRun:   true,
+		}, nil
+	}
+
+	return plannedFile(in.FileName, plan), nil
+}
+
+func plannedFile(fileName string, plan filediff.PlannedFile) *pb.ProcessedFile {
+	return &pb.ProcessedFile{
+		Processed: false,
+		FileName:  fileName,
+		Action:    fileAction(plan.Action),
+		Diff:      toFileDiff(fileName, plan.Diff),
+		DryRun:    true,
+	}
+}
+
 // This function compares the encoded contents of a file with a file currently in the path provided, it will return
-// if the current file has the same contents or if it's different
+// if the current file has the same contents or if it's different along with the line based diff between them
 func (s *fileServer) CompareFile(ctx context.Context, in *pb.File) (*pb.FileDiff, error) {
-	isEqual, err := filediff.IsSameFile(in.FileName, in.EncodedContent)
+	diff, err := s.root.DiffFile(in.FileName, in.EncodedContent)
 	if err != nil {
-		return &pb.FileDiff{}, err
+		return &pb.FileDiff{}, fileError(err)
 	}
 
-	return &pb.FileDiff{IsSame: isEqual}, nil
+	return toFileDiff(in.FileName, diff), nil
 }
 
 // This function works the same way as CompareFile, but it receives a stream of File so it can process multiple files
@@ -121,15 +327,13 @@ func (s *fileServer) CompareFiles(stream pb.FileUtils_CompareFilesServer) error
 			return err
 		}
 
-		isEqual, err := filediff.IsSameFile(in.FileName, in.EncodedContent)
+		diff, err := s.root.DiffFile(in.FileName, in.EncodedContent)
 		if err != nil {
-			return err
+			return fileError(err)
 		}
 
 		s.mu.Lock()
-		s.fileDiffs = append(s.fileDiffs, &pb.FileDiff{
-			IsSame: isEqual,
-		})
+		s.fileDiffs = append(s.fileDiffs, toFileDiff(in.FileName, diff))
 		rn := make([]*pb.FileDiff, len(s.fileDiffs))
 		copy(rn, s.fileDiffs)
 		s.mu.Unlock()
@@ -142,3 +346,149 @@ func (s *fileServer) CompareFiles(stream pb.FileUtils_CompareFilesServer) error
 		}
 	}
 }
+
+// Writes a file and checks the whole home assistant configuration afterwards, the file is restored if the check fails
+func (s *fileServer) writeCheckedFile(ctx context.Context, in *pb.File) (filediff.Action, error) {
+	tx := s.root.Begin()
+	tx.Stage(in.FileName, in.EncodedContent, writeMode(in.Mode), s.checks(in)...)
+	tx.Verify(s.checkConfig(ctx))
+
+	actions, err := tx.Commit()
+	if err != nil {
+		// When the file couldn't be restored the whole error is returned so the rollback failure is reported
+		var txErr *filediff.TransactionError
+		if errors.As(err, &txErr) && txErr.RolledBack() {
+			return filediff.Unchanged, txErr.Err
+		}
+
+		return filediff.Unchanged, err
+	}
+
+	return actions[0], nil
+}
+
+// Returns the checks that run on the contents of the file before it's written
+func (s *fileServer) checks(in *pb.File) []filediff.Check {
+	if !in.Validate {
+		return nil
+	}
+
+	return []filediff.Check{validateYAML}
+}
+
+// Returns the verification that runs the home assistant config check in the configured container
+func (s *fileServer) checkConfig(ctx context.Context) func() error {
+	return func() error {
+		return haconfig.CheckConfig(ctx, s.runtime, s.checkContainer)
+	}
+}
+
+// Validates the yaml files, any other file is accepted as it is
+func validateYAML(fileName string, contents []byte) error {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if ext != ".yaml" && ext != ".yml" {
+		return nil
+	}
+
+	return haconfig.ValidateFile(fileName, contents)
+}
+
+// This function lists the stored revisions of a file from newest to oldest
+func (s *fileServer) ListBackups(ctx context.Context, in *pb.BackupRequest) (*pb.BackupList, error) {
+	backups, err := s.root.ListBackups(in.FileName)
+	if err != nil {
+		return &pb.BackupList{}, fileError(err)
+	}
+
+	list := &pb.BackupList{}
+	for _, backup := range backups {
+		list.Backups = append(list.Backups, &pb.Backup{
+			FileName:  in.FileName,
+			Revision:  backup.Revision,
+			CreatedAt: timestamppb.New(backup.CreatedAt),
+			Size:      backup.Size,
+		})
+	}
+
+	return list, nil
+}
+
+// This function rolls a file back to one of its stored revisions, the contents being replaced are stored as a new
+// revision
+func (s *fileServer) RestoreFile(ctx context.Context, in *pb.BackupRequest) (*pb.ProcessedFile, error) {
+	action, err := s.root.RestoreFile(in.FileName, in.Revision)
+	if err != nil {
+		return &pb.ProcessedFile{}, fileError(err)
+	}
+
+	return &pb.ProcessedFile{
+		Processed: action != filediff.Unchanged,
+		FileName:  in.FileName,
+		Action:    fileAction(action),
+	}, nil
+}
+
+// Checks if the error was caused by the request itself instead of the file system
+func isRequestError(err error) bool {
+	return errors.Is(err, filediff.ErrInvalidPath) ||
+		errors.Is(err, filediff.ErrOutsideRoot) ||
+		errors.Is(err, filediff.ErrInvalidContent)
+}
+
+// Converts the errors of the file operations to grpc errors, paths that are malformed or outside of the root are
+// reported with their own codes
+func fileError(err error) error {
+	switch {
+	case errors.Is(err, filediff.ErrInvalidPath), errors.Is(err, filediff.ErrInvalidContent):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, filediff.ErrOutsideRoot):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, filediff.ErrBackupNotFound), errors.Is(err, fs.ErrNotExist):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return err
+	}
+}
+
+// Converts the diff computed by filediff to its grpc representation
+func toFileDiff(fileName string, diff filediff.Diff) *pb.FileDiff {
+	hunks := make([]*pb.DiffHunk, 0, len(diff.Hunks))
+	for _, hunk := range diff.Hunks {
+		hunks = append(hunks, &pb.DiffHunk{
+			OldStart: int32(hunk.OldStart),
+			OldLines: int32(hunk.OldLines),
+			NewStart: int32(hunk.NewStart),
+			NewLines: int32(hunk.NewLines),
+			Lines:    hunk.Lines,
+		})
+	}
+
+	return &pb.FileDiff{
+		IsSame:      diff.IsSame,
+		FileName:    fileName,
+		UnifiedDiff: diff.Unified,
+		Hunks:       hunks,
+	}
+}
+
+func writeMode(mode pb.FileMode) filediff.WriteMode {
+	switch mode {
+	case pb.FileMode_CREATE_ONLY:
+		return filediff.CreateOnly
+	case pb.FileMode_REPLACE_ONLY:
+		return filediff.ReplaceOnly
+	default:
+		return filediff.Upsert
+	}
+}
+
+func fileAction(action filediff.Action) pb.FileAction {
+	switch action {
+	case filediff.Created:
+		return pb.FileAction_CREATED
+	case filediff.Updated:
+		return pb.FileAction_UPDATED
+	default:
+		return pb.FileAction_UNCHANGED
+	}
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/aacuadras/ha-utils/lib/docker"
+	"github.com/aacuadras/ha-utils/server/pb"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	defaultHealthInterval = 10 * time.Second
+	healthCheckTimeout    = 5 * time.Second
+)
+
+// Reports the status of the services through the standard grpc health service. The docker server is not serving
+// when the container engine can't be reached and the file server is not serving when its root can't be written, the
+// whole server, the empty service name, is only serving when all of them are
+type Health struct {
+	*health.Server
+	mu       sync.Mutex
+	runtime  docker.Runtime
+	fileRoot string
+	interval time.Duration
+	statuses map[string]healthpb.HealthCheckResponse_ServingStatus
+}
+
+// Option used to configure the health checks
+type HealthOption func(*Health)
+
+// Checks the docker server by pinging the container engine of the runtime
+func WithDockerHealth(rt docker.Runtime) HealthOption {
+	return func(h *Health) {
+		h.runtime = rt
+	}
+}
+
+// Checks the file server by writing a temporary file in its root
+func WithFilesHealth(root string) HealthOption {
+	return func(h *Health) {
+		h.fileRoot = root
+	}
+}
+
+// Sets how often the services are checked, every 10 seconds by default
+func WithHealthInterval(interval time.Duration) HealthOption {
+	return func(h *Health) {
+		h.interval = interval
+	}
+}
+
+// Returns the health service, the services that are checked are not serving until they are checked for the first time
+func NewHealth(opts ...HealthOption) *Health {
+	h := &Health{
+		Server:   health.NewServer(),
+		interval: defaultHealthInterval,
+		statuses: map[string]healthpb.HealthCheckResponse_ServingStatus{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	if h.runtime != nil {
+		h.SetServingStatus(pb.DockerUtils_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	if h.fileRoot != "" {
+		h.SetServingStatus(pb.FileUtils_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	h.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return h
+}
+
+// This function checks the services at every interval until the context is done, the first check runs right away
+func (h *Health) Monitor(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		h.Update(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// This function checks every service once and updates their status, the changes are logged
+func (h *Health) Update(ctx context.Context) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	serving := true
+	if h.runtime != nil {
+		ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		err := h.runtime.Ping(ctx)
+		cancel()
+
+		serving = h.setStatus(pb.DockerUtils_ServiceDesc.ServiceName, err) && serving
+	}
+
+	if h.fileRoot != "" {
+		serving = h.setStatus(pb.FileUtils_ServiceDesc.ServiceName, checkWritable(h.fileRoot)) && serving
+	}
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if !serving {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	h.SetServingStatus("", status)
+}
+
+// Sets the status of the service from the result of its check and reports if it's serving. The lock must be held
+func (h *Health) setStatus(service string, err error) bool {
+	status := healthpb.HealthCheckResponse_SERVING
+	if err != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	if previous, ok := h.statuses[service]; !ok || previous != status {
+		if err != nil {
+			log.Printf("%s is not serving: %v", service, err)
+		} else if ok {
+			log.Printf("%s is serving again", service)
+		}
+	}
+
+	h.statuses[service] = status
+	h.SetServingStatus(service, status)
+	return err == nil
+}
+
+// Checks that files can be created in the directory by writing and removing an empty file
+func checkWritable(dir string) error {
+	file, err := os.CreateTemp(dir, ".ha-utils-health-*")
+	if err != nil {
+		return err
+	}
+
+	name := file.Name()
+	if err := file.Close(); err != nil {
+		os.Remove(name)
+		return err
+	}
+
+	return os.Remove(name)
+}
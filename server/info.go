@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	"github.com/aacuadras/ha-utils/lib/docker"
+	"github.com/aacuadras/ha-utils/server/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type infoServer struct {
+	pb.UnimplementedServerUtilsServer
+	runtime  docker.Runtime
+	version  string
+	fileRoot string
+	hostRoot string
+	services []string
+	started  time.Time
+}
+
+// Option used to configure the server info
+type InfoOption func(*infoServer)
+
+// Sets the version of the server, the version of the module in the build info is used if it's not set
+func WithVersion(version string) InfoOption {
+	return func(s *infoServer) {
+		s.version = version
+	}
+}
+
+// Sets the file root and the host root reported by the server, relative roots are reported as absolute paths
+func WithRoots(fileRoot string, hostRoot string) InfoOption {
+	return func(s *infoServer) {
+		s.fileRoot = fileRoot
+		s.hostRoot = hostRoot
+	}
+}
+
+// Sets the services that are enabled in the server
+func WithServices(services []string) InfoOption {
+	return func(s *infoServer) {
+		s.services = services
+	}
+}
+
+// Returns the server info implementation, the uptime is counted from the moment it's created
+func NewInfoServer(rt docker.Runtime, opts ...InfoOption) pb.ServerUtilsServer {
+	s := &infoServer{
+		runtime: rt,
+		started: time.Now(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.fileRoot = absPath(s.fileRoot)
+	s.hostRoot = absPath(s.hostRoot)
+
+	return s
+}
+
+// This call returns the version and build of the server, how long it has been running, the docker API version used
+// with the container engine and the roots it's configured with. The docker API version is the one negotiated the first
+// time the container engine was reached, the runtime keeps it for the whole process so it doesn't follow an engine
+// upgraded while the server runs. The info is returned even if the engine hasn't been reached yet, in which case the
+// docker API version is empty
+func (s *infoServer) GetServerInfo(ctx context.Context, in *pb.ServerInfoRequest) (*pb.ServerInfo, error) {
+	info := &pb.ServerInfo{
+		Version:       s.version,
+		Build:         &pb.BuildInfo{},
+		StartedAt:     timestamppb.New(s.started),
+		UptimeSeconds: int64(time.Since(s.started).Seconds()),
+		FileRoot:      s.fileRoot,
+		HostRoot:      s.hostRoot,
+		Services:      s.services,
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		info.Build = buildInfo(build)
+		if info.Version == "" {
+			info.Version = build.Main.Version
+		}
+	}
+
+	if s.runtime != nil {
+		if runtimeInfo, err := s.runtime.Info(ctx); err == nil {
+			info.DockerApiVersion = runtimeInfo.APIVersion
+		}
+	}
+
+	return info, nil
+}
+
+// Converts the build info of the binary, the revision is only known when it's built from a git checkout
+func buildInfo(build *debug.BuildInfo) *pb.BuildInfo {
+	info := &pb.BuildInfo{
+		GoVersion: build.GoVersion,
+		Module:    build.Main.Path,
+	}
+
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.time":
+			if revisionTime, err := time.Parse(time.RFC3339, setting.Value); err == nil {
+				info.RevisionTime = timestamppb.New(revisionTime)
+			}
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+
+	return info
+}
+
+// Returns the absolute path of the directory, empty paths are kept empty
+func absPath(dir string) string {
+	if dir == "" {
+		return ""
+	}
+
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+
+	return dir
+}
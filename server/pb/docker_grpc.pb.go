@@ -25,6 +25,19 @@ type DockerUtilsClient interface {
 	StartContainer(ctx context.Context, in *ContainerRequest, opts ...grpc.CallOption) (*ContainerResponse, error)
 	StopContainer(ctx context.Context, in *ContainerRequest, opts ...grpc.CallOption) (*ContainerResponse, error)
 	GetContainer(ctx context.Context, in *ContainerRequest, opts ...grpc.CallOption) (*ContainerResponse, error)
+	ExecContainer(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (DockerUtils_ExecContainerClient, error)
+	StreamLogs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (DockerUtils_StreamLogsClient, error)
+	RestartContainer(ctx context.Context, in *ContainerRequest, opts ...grpc.CallOption) (*ContainerResponse, error)
+	UpgradeContainer(ctx context.Context, in *UpgradeRequest, opts ...grpc.CallOption) (*ContainerResponse, error)
+	ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ContainerList, error)
+	WatchContainers(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (DockerUtils_WatchContainersClient, error)
+	GetContainerStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*ContainerStats, error)
+	StreamContainerStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (DockerUtils_StreamContainerStatsClient, error)
+	ApplyStack(ctx context.Context, in *ApplyStackRequest, opts ...grpc.CallOption) (DockerUtils_ApplyStackClient, error)
+	GetRuntimeInfo(ctx context.Context, in *RuntimeInfoRequest, opts ...grpc.CallOption) (*RuntimeInfo, error)
+	PullImage(ctx context.Context, in *PullImageRequest, opts ...grpc.CallOption) (DockerUtils_PullImageClient, error)
+	ListImages(ctx context.Context, in *ListImagesRequest, opts ...grpc.CallOption) (*ImageList, error)
+	PruneImages(ctx context.Context, in *PruneImagesRequest, opts ...grpc.CallOption) (*PruneImagesResponse, error)
 }
 
 type dockerUtilsClient struct {
@@ -62,6 +75,261 @@ func (c *dockerUtilsClient) GetContainer(ctx context.Context, in *ContainerReque
 	return out, nil
 }
 
+func (c *dockerUtilsClient) ExecContainer(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (DockerUtils_ExecContainerClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DockerUtils_ServiceDesc.Streams[0], "/DockerUtils/ExecContainer", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dockerUtilsExecContainerClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DockerUtils_ExecContainerClient interface {
+	Recv() (*ExecOutput, error)
+	grpc.ClientStream
+}
+
+type dockerUtilsExecContainerClient struct {
+	grpc.ClientStream
+}
+
+func (x *dockerUtilsExecContainerClient) Recv() (*ExecOutput, error) {
+	m := new(ExecOutput)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dockerUtilsClient) StreamLogs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (DockerUtils_StreamLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DockerUtils_ServiceDesc.Streams[1], "/DockerUtils/StreamLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dockerUtilsStreamLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DockerUtils_StreamLogsClient interface {
+	Recv() (*LogOutput, error)
+	grpc.ClientStream
+}
+
+type dockerUtilsStreamLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *dockerUtilsStreamLogsClient) Recv() (*LogOutput, error) {
+	m := new(LogOutput)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dockerUtilsClient) RestartContainer(ctx context.Context, in *ContainerRequest, opts ...grpc.CallOption) (*ContainerResponse, error) {
+	out := new(ContainerResponse)
+	err := c.cc.Invoke(ctx, "/DockerUtils/RestartContainer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerUtilsClient) UpgradeContainer(ctx context.Context, in *UpgradeRequest, opts ...grpc.CallOption) (*ContainerResponse, error) {
+	out := new(ContainerResponse)
+	err := c.cc.Invoke(ctx, "/DockerUtils/UpgradeContainer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerUtilsClient) ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ContainerList, error) {
+	out := new(ContainerList)
+	err := c.cc.Invoke(ctx, "/DockerUtils/ListContainers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerUtilsClient) WatchContainers(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (DockerUtils_WatchContainersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DockerUtils_ServiceDesc.Streams[2], "/DockerUtils/WatchContainers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dockerUtilsWatchContainersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DockerUtils_WatchContainersClient interface {
+	Recv() (*ContainerEvent, error)
+	grpc.ClientStream
+}
+
+type dockerUtilsWatchContainersClient struct {
+	grpc.ClientStream
+}
+
+func (x *dockerUtilsWatchContainersClient) Recv() (*ContainerEvent, error) {
+	m := new(ContainerEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dockerUtilsClient) GetContainerStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*ContainerStats, error) {
+	out := new(ContainerStats)
+	err := c.cc.Invoke(ctx, "/DockerUtils/GetContainerStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerUtilsClient) StreamContainerStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (DockerUtils_StreamContainerStatsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DockerUtils_ServiceDesc.Streams[3], "/DockerUtils/StreamContainerStats", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dockerUtilsStreamContainerStatsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DockerUtils_StreamContainerStatsClient interface {
+	Recv() (*ContainerStats, error)
+	grpc.ClientStream
+}
+
+type dockerUtilsStreamContainerStatsClient struct {
+	grpc.ClientStream
+}
+
+func (x *dockerUtilsStreamContainerStatsClient) Recv() (*ContainerStats, error) {
+	m := new(ContainerStats)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dockerUtilsClient) ApplyStack(ctx context.Context, in *ApplyStackRequest, opts ...grpc.CallOption) (DockerUtils_ApplyStackClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DockerUtils_ServiceDesc.Streams[4], "/DockerUtils/ApplyStack", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dockerUtilsApplyStackClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DockerUtils_ApplyStackClient interface {
+	Recv() (*StackUpdate, error)
+	grpc.ClientStream
+}
+
+type dockerUtilsApplyStackClient struct {
+	grpc.ClientStream
+}
+
+func (x *dockerUtilsApplyStackClient) Recv() (*StackUpdate, error) {
+	m := new(StackUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dockerUtilsClient) GetRuntimeInfo(ctx context.Context, in *RuntimeInfoRequest, opts ...grpc.CallOption) (*RuntimeInfo, error) {
+	out := new(RuntimeInfo)
+	err := c.cc.Invoke(ctx, "/DockerUtils/GetRuntimeInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerUtilsClient) PullImage(ctx context.Context, in *PullImageRequest, opts ...grpc.CallOption) (DockerUtils_PullImageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DockerUtils_ServiceDesc.Streams[5], "/DockerUtils/PullImage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dockerUtilsPullImageClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DockerUtils_PullImageClient interface {
+	Recv() (*PullProgress, error)
+	grpc.ClientStream
+}
+
+type dockerUtilsPullImageClient struct {
+	grpc.ClientStream
+}
+
+func (x *dockerUtilsPullImageClient) Recv() (*PullProgress, error) {
+	m := new(PullProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dockerUtilsClient) ListImages(ctx context.Context, in *ListImagesRequest, opts ...grpc.CallOption) (*ImageList, error) {
+	out := new(ImageList)
+	err := c.cc.Invoke(ctx, "/DockerUtils/ListImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerUtilsClient) PruneImages(ctx context.Context, in *PruneImagesRequest, opts ...grpc.CallOption) (*PruneImagesResponse, error) {
+	out := new(PruneImagesResponse)
+	err := c.cc.Invoke(ctx, "/DockerUtils/PruneImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DockerUtilsServer is the server API for DockerUtils service.
 // All implementations must embed UnimplementedDockerUtilsServer
 // for forward compatibility
@@ -69,6 +337,19 @@ type DockerUtilsServer interface {
 	StartContainer(context.Context, *ContainerRequest) (*ContainerResponse, error)
 	StopContainer(context.Context, *ContainerRequest) (*ContainerResponse, error)
 	GetContainer(context.Context, *ContainerRequest) (*ContainerResponse, error)
+	ExecContainer(*ExecRequest, DockerUtils_ExecContainerServer) error
+	StreamLogs(*LogsRequest, DockerUtils_StreamLogsServer) error
+	RestartContainer(context.Context, *ContainerRequest) (*ContainerResponse, error)
+	UpgradeContainer(context.Context, *UpgradeRequest) (*ContainerResponse, error)
+	ListContainers(context.Context, *ListContainersRequest) (*ContainerList, error)
+	WatchContainers(*WatchRequest, DockerUtils_WatchContainersServer) error
+	GetContainerStats(context.Context, *StatsRequest) (*ContainerStats, error)
+	StreamContainerStats(*StatsRequest, DockerUtils_StreamContainerStatsServer) error
+	ApplyStack(*ApplyStackRequest, DockerUtils_ApplyStackServer) error
+	GetRuntimeInfo(context.Context, *RuntimeInfoRequest) (*RuntimeInfo, error)
+	PullImage(*PullImageRequest, DockerUtils_PullImageServer) error
+	ListImages(context.Context, *ListImagesRequest) (*ImageList, error)
+	PruneImages(context.Context, *PruneImagesRequest) (*PruneImagesResponse, error)
 	mustEmbedUnimplementedDockerUtilsServer()
 }
 
@@ -85,6 +366,45 @@ func (UnimplementedDockerUtilsServer) StopContainer(context.Context, *ContainerR
 func (UnimplementedDockerUtilsServer) GetContainer(context.Context, *ContainerRequest) (*ContainerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetContainer not implemented")
 }
+func (UnimplementedDockerUtilsServer) ExecContainer(*ExecRequest, DockerUtils_ExecContainerServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExecContainer not implemented")
+}
+func (UnimplementedDockerUtilsServer) StreamLogs(*LogsRequest, DockerUtils_StreamLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLogs not implemented")
+}
+func (UnimplementedDockerUtilsServer) RestartContainer(context.Context, *ContainerRequest) (*ContainerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestartContainer not implemented")
+}
+func (UnimplementedDockerUtilsServer) UpgradeContainer(context.Context, *UpgradeRequest) (*ContainerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpgradeContainer not implemented")
+}
+func (UnimplementedDockerUtilsServer) ListContainers(context.Context, *ListContainersRequest) (*ContainerList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListContainers not implemented")
+}
+func (UnimplementedDockerUtilsServer) WatchContainers(*WatchRequest, DockerUtils_WatchContainersServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchContainers not implemented")
+}
+func (UnimplementedDockerUtilsServer) GetContainerStats(context.Context, *StatsRequest) (*ContainerStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContainerStats not implemented")
+}
+func (UnimplementedDockerUtilsServer) StreamContainerStats(*StatsRequest, DockerUtils_StreamContainerStatsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamContainerStats not implemented")
+}
+func (UnimplementedDockerUtilsServer) ApplyStack(*ApplyStackRequest, DockerUtils_ApplyStackServer) error {
+	return status.Errorf(codes.Unimplemented, "method ApplyStack not implemented")
+}
+func (UnimplementedDockerUtilsServer) GetRuntimeInfo(context.Context, *RuntimeInfoRequest) (*RuntimeInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRuntimeInfo not implemented")
+}
+func (UnimplementedDockerUtilsServer) PullImage(*PullImageRequest, DockerUtils_PullImageServer) error {
+	return status.Errorf(codes.Unimplemented, "method PullImage not implemented")
+}
+func (UnimplementedDockerUtilsServer) ListImages(context.Context, *ListImagesRequest) (*ImageList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListImages not implemented")
+}
+func (UnimplementedDockerUtilsServer) PruneImages(context.Context, *PruneImagesRequest) (*PruneImagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneImages not implemented")
+}
 func (UnimplementedDockerUtilsServer) mustEmbedUnimplementedDockerUtilsServer() {}
 
 // UnsafeDockerUtilsServer may be embedded to opt out of forward compatibility for this service.
@@ -152,6 +472,258 @@ func _DockerUtils_GetContainer_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DockerUtils_ExecContainer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExecRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DockerUtilsServer).ExecContainer(m, &dockerUtilsExecContainerServer{stream})
+}
+
+type DockerUtils_ExecContainerServer interface {
+	Send(*ExecOutput) error
+	grpc.ServerStream
+}
+
+type dockerUtilsExecContainerServer struct {
+	grpc.ServerStream
+}
+
+func (x *dockerUtilsExecContainerServer) Send(m *ExecOutput) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DockerUtils_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DockerUtilsServer).StreamLogs(m, &dockerUtilsStreamLogsServer{stream})
+}
+
+type DockerUtils_StreamLogsServer interface {
+	Send(*LogOutput) error
+	grpc.ServerStream
+}
+
+type dockerUtilsStreamLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *dockerUtilsStreamLogsServer) Send(m *LogOutput) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DockerUtils_RestartContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerUtilsServer).RestartContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/DockerUtils/RestartContainer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerUtilsServer).RestartContainer(ctx, req.(*ContainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerUtils_UpgradeContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpgradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerUtilsServer).UpgradeContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/DockerUtils/UpgradeContainer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerUtilsServer).UpgradeContainer(ctx, req.(*UpgradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerUtils_ListContainers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContainersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerUtilsServer).ListContainers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/DockerUtils/ListContainers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerUtilsServer).ListContainers(ctx, req.(*ListContainersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerUtils_WatchContainers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DockerUtilsServer).WatchContainers(m, &dockerUtilsWatchContainersServer{stream})
+}
+
+type DockerUtils_WatchContainersServer interface {
+	Send(*ContainerEvent) error
+	grpc.ServerStream
+}
+
+type dockerUtilsWatchContainersServer struct {
+	grpc.ServerStream
+}
+
+func (x *dockerUtilsWatchContainersServer) Send(m *ContainerEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DockerUtils_GetContainerStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerUtilsServer).GetContainerStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/DockerUtils/GetContainerStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerUtilsServer).GetContainerStats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerUtils_StreamContainerStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DockerUtilsServer).StreamContainerStats(m, &dockerUtilsStreamContainerStatsServer{stream})
+}
+
+type DockerUtils_StreamContainerStatsServer interface {
+	Send(*ContainerStats) error
+	grpc.ServerStream
+}
+
+type dockerUtilsStreamContainerStatsServer struct {
+	grpc.ServerStream
+}
+
+func (x *dockerUtilsStreamContainerStatsServer) Send(m *ContainerStats) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DockerUtils_ApplyStack_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ApplyStackRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DockerUtilsServer).ApplyStack(m, &dockerUtilsApplyStackServer{stream})
+}
+
+type DockerUtils_ApplyStackServer interface {
+	Send(*StackUpdate) error
+	grpc.ServerStream
+}
+
+type dockerUtilsApplyStackServer struct {
+	grpc.ServerStream
+}
+
+func (x *dockerUtilsApplyStackServer) Send(m *StackUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DockerUtils_GetRuntimeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RuntimeInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerUtilsServer).GetRuntimeInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/DockerUtils/GetRuntimeInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerUtilsServer).GetRuntimeInfo(ctx, req.(*RuntimeInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerUtils_PullImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PullImageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DockerUtilsServer).PullImage(m, &dockerUtilsPullImageServer{stream})
+}
+
+type DockerUtils_PullImageServer interface {
+	Send(*PullProgress) error
+	grpc.ServerStream
+}
+
+type dockerUtilsPullImageServer struct {
+	grpc.ServerStream
+}
+
+func (x *dockerUtilsPullImageServer) Send(m *PullProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DockerUtils_ListImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerUtilsServer).ListImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/DockerUtils/ListImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerUtilsServer).ListImages(ctx, req.(*ListImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerUtils_PruneImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerUtilsServer).PruneImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/DockerUtils/PruneImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerUtilsServer).PruneImages(ctx, req.(*PruneImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DockerUtils_ServiceDesc is the grpc.ServiceDesc for DockerUtils service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -171,7 +743,66 @@ var DockerUtils_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetContainer",
 			Handler:    _DockerUtils_GetContainer_Handler,
 		},
+		{
+			MethodName: "RestartContainer",
+			Handler:    _DockerUtils_RestartContainer_Handler,
+		},
+		{
+			MethodName: "UpgradeContainer",
+			Handler:    _DockerUtils_UpgradeContainer_Handler,
+		},
+		{
+			MethodName: "ListContainers",
+			Handler:    _DockerUtils_ListContainers_Handler,
+		},
+		{
+			MethodName: "GetContainerStats",
+			Handler:    _DockerUtils_GetContainerStats_Handler,
+		},
+		{
+			MethodName: "GetRuntimeInfo",
+			Handler:    _DockerUtils_GetRuntimeInfo_Handler,
+		},
+		{
+			MethodName: "ListImages",
+			Handler:    _DockerUtils_ListImages_Handler,
+		},
+		{
+			MethodName: "PruneImages",
+			Handler:    _DockerUtils_PruneImages_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExecContainer",
+			Handler:       _DockerUtils_ExecContainer_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamLogs",
+			Handler:       _DockerUtils_StreamLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchContainers",
+			Handler:       _DockerUtils_WatchContainers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamContainerStats",
+			Handler:       _DockerUtils_StreamContainerStats_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ApplyStack",
+			Handler:       _DockerUtils_ApplyStack_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PullImage",
+			Handler:       _DockerUtils_PullImage_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "docker.proto",
 }
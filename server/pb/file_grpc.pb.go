@@ -26,6 +26,8 @@ type FileUtilsClient interface {
 	SendFiles(ctx context.Context, opts ...grpc.CallOption) (FileUtils_SendFilesClient, error)
 	CompareFile(ctx context.Context, in *File, opts ...grpc.CallOption) (*FileDiff, error)
 	CompareFiles(ctx context.Context, opts ...grpc.CallOption) (FileUtils_CompareFilesClient, error)
+	ListBackups(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupList, error)
+	RestoreFile(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*ProcessedFile, error)
 }
 
 type fileUtilsClient struct {
@@ -116,6 +118,24 @@ func (x *fileUtilsCompareFilesClient) Recv() (*FileDiff, error) {
 	return m, nil
 }
 
+func (c *fileUtilsClient) ListBackups(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupList, error) {
+	out := new(BackupList)
+	err := c.cc.Invoke(ctx, "/FileUtils/ListBackups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileUtilsClient) RestoreFile(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*ProcessedFile, error) {
+	out := new(ProcessedFile)
+	err := c.cc.Invoke(ctx, "/FileUtils/RestoreFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FileUtilsServer is the server API for FileUtils service.
 // All implementations must embed UnimplementedFileUtilsServer
 // for forward compatibility
@@ -124,6 +144,8 @@ type FileUtilsServer interface {
 	SendFiles(FileUtils_SendFilesServer) error
 	CompareFile(context.Context, *File) (*FileDiff, error)
 	CompareFiles(FileUtils_CompareFilesServer) error
+	ListBackups(context.Context, *BackupRequest) (*BackupList, error)
+	RestoreFile(context.Context, *BackupRequest) (*ProcessedFile, error)
 	mustEmbedUnimplementedFileUtilsServer()
 }
 
@@ -143,6 +165,12 @@ func (UnimplementedFileUtilsServer) CompareFile(context.Context, *File) (*FileDi
 func (UnimplementedFileUtilsServer) CompareFiles(FileUtils_CompareFilesServer) error {
 	return status.Errorf(codes.Unimplemented, "method CompareFiles not implemented")
 }
+func (UnimplementedFileUtilsServer) ListBackups(context.Context, *BackupRequest) (*BackupList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBackups not implemented")
+}
+func (UnimplementedFileUtilsServer) RestoreFile(context.Context, *BackupRequest) (*ProcessedFile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreFile not implemented")
+}
 func (UnimplementedFileUtilsServer) mustEmbedUnimplementedFileUtilsServer() {}
 
 // UnsafeFileUtilsServer may be embedded to opt out of forward compatibility for this service.
@@ -244,6 +272,42 @@ func (x *fileUtilsCompareFilesServer) Recv() (*File, error) {
 	return m, nil
 }
 
+func _FileUtils_ListBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileUtilsServer).ListBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/FileUtils/ListBackups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileUtilsServer).ListBackups(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileUtils_RestoreFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileUtilsServer).RestoreFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/FileUtils/RestoreFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileUtilsServer).RestoreFile(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FileUtils_ServiceDesc is the grpc.ServiceDesc for FileUtils service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -259,6 +323,14 @@ var FileUtils_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CompareFile",
 			Handler:    _FileUtils_CompareFile_Handler,
 		},
+		{
+			MethodName: "ListBackups",
+			Handler:    _FileUtils_ListBackups_Handler,
+		},
+		{
+			MethodName: "RestoreFile",
+			Handler:    _FileUtils_RestoreFile_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,105 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v4.25.1
+// source: server.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ServerUtilsClient is the client API for ServerUtils service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ServerUtilsClient interface {
+	GetServerInfo(ctx context.Context, in *ServerInfoRequest, opts ...grpc.CallOption) (*ServerInfo, error)
+}
+
+type serverUtilsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewServerUtilsClient(cc grpc.ClientConnInterface) ServerUtilsClient {
+	return &serverUtilsClient{cc}
+}
+
+func (c *serverUtilsClient) GetServerInfo(ctx context.Context, in *ServerInfoRequest, opts ...grpc.CallOption) (*ServerInfo, error) {
+	out := new(ServerInfo)
+	err := c.cc.Invoke(ctx, "/ServerUtils/GetServerInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ServerUtilsServer is the server API for ServerUtils service.
+// All implementations must embed UnimplementedServerUtilsServer
+// for forward compatibility
+type ServerUtilsServer interface {
+	GetServerInfo(context.Context, *ServerInfoRequest) (*ServerInfo, error)
+	mustEmbedUnimplementedServerUtilsServer()
+}
+
+// UnimplementedServerUtilsServer must be embedded to have forward compatible implementations.
+type UnimplementedServerUtilsServer struct {
+}
+
+func (UnimplementedServerUtilsServer) GetServerInfo(context.Context, *ServerInfoRequest) (*ServerInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServerInfo not implemented")
+}
+func (UnimplementedServerUtilsServer) mustEmbedUnimplementedServerUtilsServer() {}
+
+// UnsafeServerUtilsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ServerUtilsServer will
+// result in compilation errors.
+type UnsafeServerUtilsServer interface {
+	mustEmbedUnimplementedServerUtilsServer()
+}
+
+func RegisterServerUtilsServer(s grpc.ServiceRegistrar, srv ServerUtilsServer) {
+	s.RegisterService(&ServerUtils_ServiceDesc, srv)
+}
+
+func _ServerUtils_GetServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerUtilsServer).GetServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ServerUtils/GetServerInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerUtilsServer).GetServerInfo(ctx, req.(*ServerInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ServerUtils_ServiceDesc is the grpc.ServiceDesc for ServerUtils service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ServerUtils_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ServerUtils",
+	HandlerType: (*ServerUtilsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetServerInfo",
+			Handler:    _ServerUtils_GetServerInfo_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "server.proto",
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Services whose calls are cancelled when the server shuts down, the docker calls can wait on containers for minutes
+// or follow them forever and the health checks can be watched forever
+var cancelledServices = []string{"/DockerUtils/", "/" + healthpb.Health_ServiceDesc.ServiceName + "/"}
+
+// Tracks the calls in progress so the server can stop without cutting them off. Calls to the docker server and the
+// health service are cancelled through their context when the server shuts down, while calls to the file server run
+// until they finish so their writes are either completed or rolled back
+type Shutdown struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	calls  sync.WaitGroup
+}
+
+// Returns the tracker of the calls in progress, its interceptors must be installed in the server
+func NewShutdown() *Shutdown {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Shutdown{ctx: ctx, cancel: cancel}
+}
+
+// This function returns an interceptor that tracks the unary calls and cancels the ones of the docker server when it
+// shuts down
+func (sh *Shutdown) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		sh.calls.Add(1)
+		defer sh.calls.Done()
+
+		ctx, cancel := sh.callContext(ctx, info.FullMethod)
+		defer cancel()
+
+		return handler(ctx, req)
+	}
+}
+
+// This function returns an interceptor that tracks the streaming calls and cancels the ones of the docker server when
+// it shuts down
+func (sh *Shutdown) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		sh.calls.Add(1)
+		defer sh.calls.Done()
+
+		ctx, cancel := sh.callContext(stream.Context(), info.FullMethod)
+		defer cancel()
+
+		return handler(srv, &shutdownStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// This function stops the server waiting up to the timeout for the calls in progress, the docker calls are cancelled
+// right away. When the timeout expires the remaining connections are closed, which rolls back the transactions that
+// were still being received. It returns once every call has returned and reports if the server drained in time
+func (sh *Shutdown) Stop(s *grpc.Server, timeout time.Duration) bool {
+	sh.cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	drained := true
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		log.Printf("The calls in progress didn't finish in %s, closing the connections", timeout)
+		s.Stop()
+		drained = false
+	}
+
+	// Closing the connections doesn't wait for the handlers, the files being written must be done before exiting
+	sh.calls.Wait()
+	return drained
+}
+
+// Returns the context of a call, the calls to the docker server and the health service are also cancelled when the
+// server shuts down
+func (sh *Shutdown) callContext(ctx context.Context, method string) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	if !cancelledOnShutdown(method) {
+		return ctx, cancel
+	}
+
+	go func() {
+		select {
+		case <-sh.ctx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// Reports if the calls of the method are cancelled when the server shuts down
+func cancelledOnShutdown(method string) bool {
+	for _, prefix := range cancelledServices {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Stream whose context is the one of the call
+type shutdownStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *shutdownStream) Context() context.Context {
+	return s.ctx
+}
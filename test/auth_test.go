@@ -0,0 +1,478 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aacuadras/ha-utils/lib/auth"
+	"github.com/aacuadras/ha-utils/lib/certs"
+	"github.com/aacuadras/ha-utils/lib/docker"
+	"github.com/aacuadras/ha-utils/server"
+	"github.com/aacuadras/ha-utils/server/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Starts the docker, file, info and health servers behind the auth interceptors, the docker server uses the fake
+// runtime and the file root has a few home assistant files
+func createAuthServer(t *testing.T, policy *auth.Policy, opts ...grpc.ServerOption) *bufconn.Listener {
+	listener := bufconn.Listen(1024 * 1024)
+
+	root := t.TempDir()
+	for _, file := range []string{"automations.yaml", "secrets.yaml", "packages/lights/kitchen.yaml"} {
+		assert.Nil(t, os.MkdirAll(filepath.Dir(filepath.Join(root, file)), 0700))
+		assert.Nil(t, os.WriteFile(filepath.Join(root, file), []byte("{}\n"), 0600))
+	}
+
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(server.UnaryAuthInterceptor(policy)),
+		grpc.ChainStreamInterceptor(server.StreamAuthInterceptor(policy)),
+	)
+	s := grpc.NewServer(opts...)
+	pb.RegisterDockerUtilsServer(s, server.NewServer(docker.NewFake()))
+	pb.RegisterFileUtilsServer(s, server.NewFileServer(server.WithFileRoot(root)))
+	pb.RegisterServerUtilsServer(s, server.NewInfoServer(nil))
+	healthpb.RegisterHealthServer(s, server.NewHealth())
+	go s.Serve(listener)
+	t.Cleanup(s.Stop)
+
+	return listener
+}
+
+func dialAuthServer(t *testing.T, listener *bufconn.Listener, creds credentials.TransportCredentials) *grpc.ClientConn {
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+		return listener.Dial()
+	}), grpc.WithTransportCredentials(creds))
+	assert.Nil(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestParsePolicy(t *testing.T) {
+	hash := auth.HashToken("secret")
+
+	testCases := map[string]struct {
+		policy string
+		err    bool
+	}{
+		"valid": {
+			policy: fmt.Sprintf("principals:\n  - name: automation\n    tokens: [%s]\n    rpcs: [FileUtils/*]\n    paths: [automations/**]\n", hash),
+		},
+		"plaintext_token": {
+			policy: "principals:\n  - name: automation\n    tokens: [secret]\n",
+			err:    true,
+		},
+		"unknown_field": {
+			policy: "principals:\n  - name: automation\n    rpc: [FileUtils/*]\n",
+			err:    true,
+		},
+		"duplicated_principal": {
+			policy: "principals:\n  - name: automation\n  - name: automation\n",
+			err:    true,
+		},
+		"missing_name": {
+			policy: "principals:\n  - rpcs: ['*']\n",
+			err:    true,
+		},
+		"invalid_glob": {
+			policy: "principals:\n  - name: automation\n    containers: ['ha-[']\n",
+			err:    true,
+		},
+	}
+
+	for scenario, testcase := range testCases {
+		t.Run(scenario, func(t *testing.T) {
+			_, err := auth.ParsePolicy([]byte(testcase.policy))
+
+			if testcase.err {
+				assert.ErrorIs(t, err, auth.ErrInvalidPolicy)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthInterceptors(t *testing.T) {
+	policy, err := auth.ParsePolicy([]byte(fmt.Sprintf(`
+principals:
+  - name: admin
+    tokens: [%s]
+    rpcs: ["*"]
+  - name: automation
+    tokens: [%s]
+    rpcs: [FileUtils/CompareFile, FileUtils/CompareFiles, DockerUtils/StartContainer]
+    paths: [automations.yaml, packages/**]
+    containers: [ha-*]
+`, auth.HashToken("admin-token"), auth.HashToken("automation-token"))))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	conn := dialAuthServer(t, createAuthServer(t, policy), insecure.NewCredentials())
+	files := pb.NewFileUtilsClient(conn)
+	containers := pb.NewDockerUtilsClient(conn)
+	info := pb.NewServerUtilsClient(conn)
+	health := healthpb.NewHealthClient(conn)
+
+	testCases := map[string]struct {
+		token string
+		call  func(ctx context.Context) error
+		code  codes.Code
+	}{
+		"no_token": {
+			call: func(ctx context.Context) error {
+				_, err := containers.GetRuntimeInfo(ctx, &pb.RuntimeInfoRequest{})
+				return err
+			},
+			code: codes.Unauthenticated,
+		},
+		"health_without_token": {
+			call: func(ctx context.Context) error {
+				_, err := health.Check(ctx, &healthpb.HealthCheckRequest{})
+				return err
+			},
+			code: codes.OK,
+		},
+		"info_without_token": {
+			call: func(ctx context.Context) error {
+				_, err := info.GetServerInfo(ctx, &pb.ServerInfoRequest{})
+				return err
+			},
+			code: codes.Unauthenticated,
+		},
+		"info_not_allowed": {
+			token: "automation-token",
+			call: func(ctx context.Context) error {
+				_, err := info.GetServerInfo(ctx, &pb.ServerInfoRequest{})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		"unknown_token": {
+			token: "guess",
+			call: func(ctx context.Context) error {
+				_, err := containers.GetRuntimeInfo(ctx, &pb.RuntimeInfoRequest{})
+				return err
+			},
+			code: codes.Unauthenticated,
+		},
+		"admin": {
+			token: "admin-token",
+			call: func(ctx context.Context) error {
+				_, err := files.SendFile(ctx, &pb.File{FileName: "configuration.yaml", EncodedContent: encondeFileContent("a: 1\n")})
+				return err
+			},
+			code: codes.OK,
+		},
+		"allowed_path": {
+			token: "automation-token",
+			call: func(ctx context.Context) error {
+				_, err := files.CompareFile(ctx, &pb.File{FileName: "./packages/lights/kitchen.yaml"})
+				return err
+			},
+			code: codes.OK,
+		},
+		"denied_rpc": {
+			token: "automation-token",
+			call: func(ctx context.Context) error {
+				_, err := files.SendFile(ctx, &pb.File{FileName: "automations.yaml"})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		"denied_path": {
+			token: "automation-token",
+			call: func(ctx context.Context) error {
+				_, err := files.CompareFile(ctx, &pb.File{FileName: "secrets.yaml"})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		"path_traversal": {
+			token: "automation-token",
+			call: func(ctx context.Context) error {
+				_, err := files.CompareFile(ctx, &pb.File{FileName: "packages/../secrets.yaml"})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		"denied_path_in_stream": {
+			token: "automation-token",
+			call: func(ctx context.Context) error {
+				stream, err := files.CompareFiles(ctx)
+				if err != nil {
+					return err
+				}
+
+				stream.Send(&pb.File{FileName: "automations.yaml"})
+				stream.Send(&pb.File{FileName: "secrets.yaml"})
+				stream.CloseSend()
+
+				for {
+					if _, err := stream.Recv(); err != nil {
+						return err
+					}
+				}
+			},
+			code: codes.PermissionDenied,
+		},
+		"allowed_container": {
+			token: "automation-token",
+			call: func(ctx context.Context) error {
+				_, err := containers.StartContainer(ctx, &pb.ContainerRequest{ContainerName: "ha-test"})
+				return err
+			},
+			code: codes.OK,
+		},
+		"denied_container": {
+			token: "automation-token",
+			call: func(ctx context.Context) error {
+				_, err := containers.StartContainer(ctx, &pb.ContainerRequest{ContainerName: "mosquitto"})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+	}
+
+	for scenario, testcase := range testCases {
+		t.Run(scenario, func(t *testing.T) {
+			ctx := context.Background()
+			if testcase.token != "" {
+				ctx = withToken(testcase.token)
+			}
+
+			assert.Equal(t, testcase.code, status.Code(testcase.call(ctx)))
+		})
+	}
+}
+
+func TestAuthContainerScope(t *testing.T) {
+	policy, err := auth.ParsePolicy([]byte(fmt.Sprintf(`
+principals:
+  - name: admin
+    tokens: [%s]
+    rpcs: ["*"]
+  - name: operator
+    tokens: [%s]
+    rpcs: [DockerUtils/*]
+    containers: [ha-*]
+  - name: editor
+    tokens: [%s]
+    rpcs: ["*"]
+    paths: [automations.yaml]
+`, auth.HashToken("admin-token"), auth.HashToken("operator-token"), auth.HashToken("editor-token"))))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	conn := dialAuthServer(t, createAuthServer(t, policy), insecure.NewCredentials())
+	containers := pb.NewDockerUtilsClient(conn)
+
+	start := func(in *pb.ContainerRequest) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			_, err := containers.StartContainer(ctx, in)
+			return err
+		}
+	}
+
+	stream := func(open func(ctx context.Context) (grpc.ClientStream, error)) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			s, err := open(ctx)
+			if err != nil {
+				return err
+			}
+
+			// The interceptor runs when the first message is received
+			return s.RecvMsg(&pb.PullProgress{})
+		}
+	}
+
+	testCases := map[string]struct {
+		token string
+		call  func(ctx context.Context) error
+		code  codes.Code
+	}{
+		"list_containers": {
+			token: "operator-token",
+			call: func(ctx context.Context) error {
+				_, err := containers.ListContainers(ctx, &pb.ListContainersRequest{})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		"pull_image": {
+			token: "operator-token",
+			call: stream(func(ctx context.Context) (grpc.ClientStream, error) {
+				return containers.PullImage(ctx, &pb.PullImageRequest{Image: "eclipse-mosquitto"})
+			}),
+			code: codes.PermissionDenied,
+		},
+		"prune_images": {
+			token: "operator-token",
+			call: func(ctx context.Context) error {
+				_, err := containers.PruneImages(ctx, &pb.PruneImagesRequest{DryRun: true})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		"list_images": {
+			token: "operator-token",
+			call: func(ctx context.Context) error {
+				_, err := containers.ListImages(ctx, &pb.ListImagesRequest{})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		"start_with_image": {
+			token: "operator-token",
+			call:  start(&pb.ContainerRequest{ContainerName: "ha-mqtt", Image: "eclipse-mosquitto"}),
+			code:  codes.PermissionDenied,
+		},
+		"start_with_tag": {
+			token: "operator-token",
+			call:  start(&pb.ContainerRequest{ContainerName: "ha-core", Tag: "2024.1"}),
+			code:  codes.PermissionDenied,
+		},
+		"upgrade_to_image": {
+			token: "operator-token",
+			call: func(ctx context.Context) error {
+				_, err := containers.UpgradeContainer(ctx, &pb.UpgradeRequest{ContainerName: "ha-core", Image: "eclipse-mosquitto"})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		"upgrade_to_tag": {
+			token: "operator-token",
+			call: func(ctx context.Context) error {
+				_, err := containers.UpgradeContainer(ctx, &pb.UpgradeRequest{ContainerName: "ha-core", Tag: "2024.1"})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		"upgrade_current_image": {
+			token: "operator-token",
+			call: func(ctx context.Context) error {
+				// The container doesn't exist, so the call is only allowed to get this far
+				_, err := containers.UpgradeContainer(ctx, &pb.UpgradeRequest{ContainerName: "ha-missing"})
+				return err
+			},
+			code: codes.NotFound,
+		},
+		"start_with_image_unlimited": {
+			token: "admin-token",
+			call:  start(&pb.ContainerRequest{ContainerName: "mosquitto", Image: "eclipse-mosquitto"}),
+			code:  codes.OK,
+		},
+		"anonymous_volume": {
+			token: "operator-token",
+			call:  start(&pb.ContainerRequest{ContainerName: "ha-core", Mounts: []*pb.Mount{{Target: "/data"}}}),
+			code:  codes.OK,
+		},
+		"named_volume": {
+			token: "operator-token",
+			call:  start(&pb.ContainerRequest{ContainerName: "ha-core", Mounts: []*pb.Mount{{Source: "homeassistant-config", Target: "/config"}}}),
+			code:  codes.PermissionDenied,
+		},
+		"host_network": {
+			token: "operator-token",
+			call:  start(&pb.ContainerRequest{ContainerName: "ha-core", NetworkMode: "host"}),
+			code:  codes.PermissionDenied,
+		},
+		"container_network": {
+			token: "operator-token",
+			call:  start(&pb.ContainerRequest{ContainerName: "ha-core", NetworkMode: "container:mosquitto"}),
+			code:  codes.PermissionDenied,
+		},
+		"bridge_network": {
+			token: "operator-token",
+			call:  start(&pb.ContainerRequest{ContainerName: "ha-bridge", NetworkMode: "bridge"}),
+			code:  codes.OK,
+		},
+		"privileged": {
+			token: "operator-token",
+			call:  start(&pb.ContainerRequest{ContainerName: "ha-core", Privileged: true}),
+			code:  codes.PermissionDenied,
+		},
+		"device": {
+			token: "operator-token",
+			call:  start(&pb.ContainerRequest{ContainerName: "ha-core", Devices: []*pb.DeviceMapping{{HostPath: "/dev/ttyUSB0"}}}),
+			code:  codes.PermissionDenied,
+		},
+		"bind_mount": {
+			token: "operator-token",
+			call:  start(&pb.ContainerRequest{ContainerName: "ha-core", Mounts: []*pb.Mount{{Source: "/etc", Target: "/host"}}}),
+			code:  codes.PermissionDenied,
+		},
+		"config_dir_with_limited_paths": {
+			token: "editor-token",
+			call:  start(&pb.ContainerRequest{ContainerName: "homeassistant", ConfigDir: "/srv/homeassistant"}),
+			code:  codes.PermissionDenied,
+		},
+		"privileged_stack_with_limited_paths": {
+			token: "editor-token",
+			call: stream(func(ctx context.Context) (grpc.ClientStream, error) {
+				return containers.ApplyStack(ctx, &pb.ApplyStackRequest{
+					DryRun: true,
+					Spec:   &pb.ApplyStackRequest_Yaml{Yaml: "name: home\nservices:\n  - name: mqtt\n    image: eclipse-mosquitto\n    privileged: true\n"},
+				})
+			}),
+			code: codes.PermissionDenied,
+		},
+		"privileged_unlimited": {
+			token: "admin-token",
+			call:  start(&pb.ContainerRequest{ContainerName: "homeassistant", Privileged: true}),
+			code:  codes.OK,
+		},
+	}
+
+	for scenario, testcase := range testCases {
+		t.Run(scenario, func(t *testing.T) {
+			assert.Equal(t, testcase.code, status.Code(testcase.call(withToken(testcase.token))))
+		})
+	}
+}
+
+func TestAuthClientCertificate(t *testing.T) {
+	bundle, err := certs.GenerateLocalCA(t.TempDir(), []string{"localhost"})
+	assert.Nil(t, err)
+
+	reloader, err := certs.NewReloader(bundle.ServerCert, bundle.ServerKey, certs.WithClientCA(bundle.CACert))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// The generated client certificate is named ha-utils-client
+	policy, err := auth.ParsePolicy([]byte("principals:\n  - name: ha-utils-client\n    rpcs: [DockerUtils/GetRuntimeInfo]\n"))
+	assert.Nil(t, err)
+
+	listener := createAuthServer(t, policy, grpc.Creds(credentials.NewTLS(reloader.ServerConfig())))
+	conn := dialAuthServer(t, listener, credentials.NewTLS(clientTLSConfig(t, bundle, true)))
+	client := pb.NewDockerUtilsClient(conn)
+
+	_, err = client.GetRuntimeInfo(context.Background(), &pb.RuntimeInfoRequest{})
+	assert.Nil(t, err)
+
+	_, err = client.ListContainers(context.Background(), &pb.ListContainersRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// A bearer token takes precedence over the certificate
+	_, err = client.GetRuntimeInfo(withToken("guess"), &pb.RuntimeInfoRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
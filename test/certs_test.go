@@ -0,0 +1,198 @@
+package test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aacuadras/ha-utils/lib/certs"
+	"github.com/aacuadras/ha-utils/lib/docker"
+	"github.com/aacuadras/ha-utils/server"
+	"github.com/aacuadras/ha-utils/server/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Starts a docker server with the TLS configuration and returns a function that dials it with the client configuration
+func createTLSServer(t *testing.T, config *tls.Config) func(*tls.Config) (pb.DockerUtilsClient, error) {
+	listener := bufconn.Listen(1024 * 1024)
+
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(config)))
+	pb.RegisterDockerUtilsServer(s, server.NewServer(docker.NewFake()))
+	go s.Serve(listener)
+	t.Cleanup(s.Stop)
+
+	return func(clientConfig *tls.Config) (pb.DockerUtilsClient, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.Dial()
+		}), grpc.WithTransportCredentials(credentials.NewTLS(clientConfig)))
+		if err != nil {
+			return nil, err
+		}
+		t.Cleanup(func() { conn.Close() })
+
+		client := pb.NewDockerUtilsClient(conn)
+		_, err = client.GetRuntimeInfo(ctx, &pb.RuntimeInfoRequest{})
+		return client, err
+	}
+}
+
+// Returns the client configuration that trusts the CA of the bundle, presenting the client certificate if it's set
+func clientTLSConfig(t *testing.T, bundle certs.Bundle, withCertificate bool) *tls.Config {
+	caPEM, err := os.ReadFile(bundle.CACert)
+	assert.Nil(t, err)
+
+	pool := x509.NewCertPool()
+	assert.True(t, pool.AppendCertsFromPEM(caPEM))
+
+	config := &tls.Config{RootCAs: pool, ServerName: "localhost"}
+	if withCertificate {
+		certificate, err := tls.LoadX509KeyPair(bundle.ClientCert, bundle.ClientKey)
+		assert.Nil(t, err)
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config
+}
+
+func TestGenerateLocalCA(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "certs")
+
+	bundle, err := certs.GenerateLocalCA(dir, []string{"localhost", "192.168.1.10"})
+	assert.Nil(t, err)
+
+	info, err := os.Stat(bundle.CAKey)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	ca, err := certs.LoadCA(bundle.CACert, bundle.CAKey)
+	assert.Nil(t, err)
+
+	serverPair, err := tls.LoadX509KeyPair(bundle.ServerCert, bundle.ServerKey)
+	assert.Nil(t, err)
+	serverCert, err := x509.ParseCertificate(serverPair.Certificate[0])
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"localhost"}, serverCert.DNSNames)
+	assert.Equal(t, "192.168.1.10", serverCert.IPAddresses[0].String())
+	assert.Nil(t, serverCert.CheckSignatureFrom(ca.Certificate))
+
+	// Generating them again keeps the CA so the clients keep trusting it
+	again, err := certs.GenerateLocalCA(dir, []string{"localhost"})
+	assert.Nil(t, err)
+	reloaded, err := certs.LoadCA(again.CACert, again.CAKey)
+	assert.Nil(t, err)
+	assert.Equal(t, ca.Certificate.Raw, reloaded.Certificate.Raw)
+
+	_, err = certs.LoadCA(bundle.ServerCert, bundle.ServerKey)
+	assert.ErrorIs(t, err, certs.ErrInvalidCertificate)
+}
+
+func TestMutualTLS(t *testing.T) {
+	bundle, err := certs.GenerateLocalCA(t.TempDir(), []string{"localhost"})
+	assert.Nil(t, err)
+
+	reloader, err := certs.NewReloader(bundle.ServerCert, bundle.ServerKey, certs.WithClientCA(bundle.CACert))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	dial := createTLSServer(t, reloader.ServerConfig())
+
+	_, err = dial(clientTLSConfig(t, bundle, true))
+	assert.Nil(t, err)
+
+	_, err = dial(clientTLSConfig(t, bundle, false))
+	assert.NotNil(t, err)
+
+	// A client certificate from another CA is rejected
+	other, err := certs.GenerateLocalCA(t.TempDir(), []string{"localhost"})
+	assert.Nil(t, err)
+	untrusted := clientTLSConfig(t, bundle, false)
+	certificate, err := tls.LoadX509KeyPair(other.ClientCert, other.ClientKey)
+	assert.Nil(t, err)
+	untrusted.Certificates = []tls.Certificate{certificate}
+
+	_, err = dial(untrusted)
+	assert.NotNil(t, err)
+}
+
+func TestReloadCertificates(t *testing.T) {
+	bundle, err := certs.GenerateLocalCA(t.TempDir(), []string{"localhost"})
+	assert.Nil(t, err)
+
+	reloader, err := certs.NewReloader(bundle.ServerCert, bundle.ServerKey, certs.WithReloadInterval(10*time.Millisecond))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go reloader.Watch(ctx)
+
+	dial := createTLSServer(t, reloader.ServerConfig())
+
+	_, err = dial(clientTLSConfig(t, bundle, false))
+	assert.Nil(t, err)
+
+	// Rotate the server certificate to one issued by a new CA, the clients that only trust the old one are rejected
+	rotated, err := certs.GenerateLocalCA(t.TempDir(), []string{"localhost"})
+	assert.Nil(t, err)
+
+	for _, file := range [][2]string{{rotated.ServerCert, bundle.ServerCert}, {rotated.ServerKey, bundle.ServerKey}} {
+		content, err := os.ReadFile(file[0])
+		assert.Nil(t, err)
+		assert.Nil(t, os.WriteFile(file[1], content, 0600))
+	}
+
+	assert.Eventually(t, func() bool {
+		_, err := dial(clientTLSConfig(t, rotated, false))
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+
+	_, err = dial(clientTLSConfig(t, bundle, false))
+	assert.NotNil(t, err)
+
+	// A broken key keeps the certificate loaded last
+	assert.Nil(t, os.WriteFile(bundle.ServerKey, []byte("not a key"), 0600))
+	assert.NotNil(t, reloader.Reload())
+
+	_, err = dial(clientTLSConfig(t, rotated, false))
+	assert.Nil(t, err)
+}
+
+func TestNegotiateHTTP2(t *testing.T) {
+	bundle, err := certs.GenerateLocalCA(t.TempDir(), []string{"localhost"})
+	assert.Nil(t, err)
+
+	reloader, err := certs.NewReloader(bundle.ServerCert, bundle.ServerKey)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	serverConn, clientConn := net.Pipe()
+	defer clientConn.Close()
+
+	go func() {
+		defer serverConn.Close()
+		credentials.NewTLS(reloader.ServerConfig()).ServerHandshake(serverConn)
+	}()
+
+	config := clientTLSConfig(t, bundle, false)
+	config.NextProtos = []string{"h2"}
+	conn := tls.Client(clientConn, config)
+	if !assert.Nil(t, conn.Handshake()) {
+		return
+	}
+
+	assert.Equal(t, "h2", conn.ConnectionState().NegotiatedProtocol)
+}
@@ -0,0 +1,143 @@
+package test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aacuadras/ha-utils/lib/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadConfig(t *testing.T, args []string, env map[string]string) (*config.Config, error) {
+	fs := flag.NewFlagSet("ha-utils", flag.ContinueOnError)
+	flags := config.NewFlags(fs)
+	assert.Nil(t, fs.Parse(args))
+
+	return flags.Load(func(name string) string {
+		return env[name]
+	})
+}
+
+func TestLoadConfig(t *testing.T) {
+	root := t.TempDir()
+	configFile := filepath.Join(t.TempDir(), "ha-utils.yaml")
+	assert.Nil(t, os.WriteFile(configFile, []byte(`
+listen:
+  address: 0.0.0.0:9090
+  socket: /run/ha-utils.sock
+files:
+  root: `+root+`
+  backupRetention: 3
+containers:
+  image: ghcr.io/home-assistant/home-assistant
+  tag: stable
+timezone: Europe/Madrid
+services: [files]
+`), 0600))
+
+	cfg, err := loadConfig(t, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, config.Default(), cfg)
+
+	cfg, err = loadConfig(t, []string{"-config", configFile}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.0.0.0:9090", cfg.Listen.Address)
+	assert.Equal(t, "/run/ha-utils.sock", cfg.Listen.Socket)
+	assert.Equal(t, 3, cfg.Files.BackupRetention)
+	assert.Equal(t, "stable", cfg.Containers.Tag)
+	assert.True(t, cfg.Enabled(config.ServiceFiles))
+	assert.False(t, cfg.Enabled(config.ServiceDocker))
+	// Settings missing from the file keep their defaults
+	assert.Equal(t, "info", cfg.LogLevel)
+
+	// The environment overrides the file and the flags override both
+	cfg, err = loadConfig(t, []string{"-timezone", "UTC"}, map[string]string{
+		"HA_UTILS_CONFIG":           configFile,
+		"HA_UTILS_TIMEZONE":         "America/New_York",
+		"HA_UTILS_BACKUP_RETENTION": "0",
+		"HA_UTILS_SERVICES":         "docker, files",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "UTC", cfg.Timezone)
+	assert.Equal(t, 0, cfg.Files.BackupRetention)
+	assert.Equal(t, []string{"docker", "files"}, cfg.Services)
+	assert.Equal(t, "0.0.0.0:9090", cfg.Listen.Address)
+}
+
+func TestValidateConfig(t *testing.T) {
+	testCases := map[string]struct {
+		args     []string
+		env      map[string]string
+		problems []string
+	}{
+		"unknown_field": {
+			env:      map[string]string{"HA_UTILS_CONFIG": writeConfig(t, "listen:\n  adress: 0.0.0.0:8080\n")},
+			problems: []string{"field adress not found"},
+		},
+		"missing_config_file": {
+			args:     []string{"-config", "/nonexistent/ha-utils.yaml"},
+			problems: []string{"no such file"},
+		},
+		"invalid_number": {
+			env:      map[string]string{"HA_UTILS_BACKUP_RETENTION": "ten"},
+			problems: []string{"HA_UTILS_BACKUP_RETENTION"},
+		},
+		"invalid_shutdown_timeout": {
+			env:      map[string]string{"HA_UTILS_SHUTDOWN_TIMEOUT": "30"},
+			problems: []string{"HA_UTILS_SHUTDOWN_TIMEOUT"},
+		},
+		"no_shutdown_timeout": {
+			args:     []string{"-shutdown-timeout", "0s"},
+			problems: []string{"shutdownTimeout: it must be positive"},
+		},
+		"nowhere_to_listen": {
+			args:     []string{"-listen-address", ""},
+			problems: []string{"an address or a unix socket is required"},
+		},
+		"several_problems": {
+			args: []string{
+				"-listen-address", "localhost:http-alt",
+				"-listen-socket", "ha-utils.sock",
+				"-tls-key", "server-key.pem",
+				"-restart-policy", "sometimes",
+				"-log-level", "trace",
+				"-services", "docker,ftp",
+				"-file-root", "/nonexistent",
+			},
+			problems: []string{
+				"listen.address: invalid port",
+				"listen.socket",
+				"tls: the certificate and key must be set together",
+				"tls.key",
+				"containers.restartPolicy",
+				"logLevel",
+				`unknown service "ftp"`,
+				"files.root",
+			},
+		},
+	}
+
+	for scenario, testcase := range testCases {
+		t.Run(scenario, func(t *testing.T) {
+			_, err := loadConfig(t, testcase.args, testcase.env)
+			if !assert.ErrorIs(t, err, config.ErrInvalidConfig) {
+				return
+			}
+
+			for _, problem := range testcase.problems {
+				assert.Contains(t, err.Error(), problem)
+			}
+			assert.Equal(t, len(testcase.problems) > 1, strings.Count(err.Error(), "\n") > 1)
+		})
+	}
+}
+
+func writeConfig(t *testing.T, content string) string {
+	file := filepath.Join(t.TempDir(), "ha-utils.yaml")
+	assert.Nil(t, os.WriteFile(file, []byte(content), 0600))
+
+	return file
+}
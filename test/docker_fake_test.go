@@ -0,0 +1,559 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/aacuadras/ha-utils/lib/docker"
+	"github.com/aacuadras/ha-utils/server"
+	"github.com/aacuadras/ha-utils/server/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFakeStartStopContainer(t *testing.T) {
+	ctx := context.Background()
+	fake := docker.NewFake()
+
+	client, closer := createRuntimeClient(ctx, fake)
+	defer closer()
+
+	request := pb.ContainerRequest{
+		ContainerName: "mosquitto",
+		Image:         "eclipse-mosquitto",
+		Tag:           "2",
+		Env:           map[string]string{"TZ": "UTC"},
+	}
+
+	out, err := client.StartContainer(ctx, &request)
+	assert.Nil(t, err)
+	assert.Equal(t, "running", out.Status)
+	assert.NotEmpty(t, out.ContainerId)
+
+	info, err := fake.GetContainer(ctx, "mosquitto")
+	assert.Nil(t, err)
+	assert.Equal(t, "eclipse-mosquitto:2", info.Config.Image)
+	assert.Contains(t, info.Config.Env, "TZ=UTC")
+
+	_, err = client.StartContainer(ctx, &request)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	got, err := client.GetContainer(ctx, &request)
+	assert.Nil(t, err)
+	assert.Equal(t, out.ContainerId, got.ContainerId)
+
+	_, err = client.StopContainer(ctx, &request)
+	assert.Nil(t, err)
+
+	got, err = client.GetContainer(ctx, &request)
+	assert.Nil(t, err)
+	assert.Empty(t, got.ContainerId)
+
+	_, err = client.RestartContainer(ctx, &request)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.StopContainer(ctx, &request)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestFakeStartFailure(t *testing.T) {
+	ctx := context.Background()
+	fake := docker.NewFake()
+
+	client, closer := createRuntimeClient(ctx, fake)
+	defer closer()
+
+	request := pb.ContainerRequest{ContainerName: "mosquitto", Image: "eclipse-mosquitto"}
+	fake.FailStart("mosquitto", errors.New("port 1883 is already allocated"))
+
+	_, err := client.StartContainer(ctx, &request)
+	assert.ErrorContains(t, err, "port 1883 is already allocated")
+
+	// The failed container doesn't take the name, so it can be started again
+	got, err := client.GetContainer(ctx, &request)
+	assert.Nil(t, err)
+	assert.Empty(t, got.ContainerId)
+
+	out, err := client.StartContainer(ctx, &request)
+	assert.Nil(t, err)
+	assert.Equal(t, "running", out.Status)
+}
+
+// Runtime that wraps the errors of the fake like the engine does
+type wrappingRuntime struct {
+	*docker.Fake
+}
+
+func (r wrappingRuntime) StopContainer(ctx context.Context, containerName string) error {
+	if err := r.Fake.StopContainer(ctx, containerName); err != nil {
+		return fmt.Errorf("unable to stop container %s: %w", containerName, err)
+	}
+
+	return nil
+}
+
+func TestFakeStopMissingContainer(t *testing.T) {
+	ctx := context.Background()
+
+	client, closer := createRuntimeClient(ctx, wrappingRuntime{docker.NewFake()})
+	defer closer()
+
+	_, err := client.StopContainer(ctx, &pb.ContainerRequest{ContainerName: "mosquitto"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestFakeExecContainer(t *testing.T) {
+	ctx := context.Background()
+	fake := docker.NewFake()
+	fake.SetExecHandler(func(containerName string, options docker.ExecOptions, stdout io.Writer, stderr io.Writer) int {
+		fmt.Fprintln(stdout, options.Env[0])
+		fmt.Fprintln(stderr, "failed")
+		return 3
+	})
+
+	client, closer := createRuntimeClient(ctx, fake)
+	defer closer()
+
+	_, err := client.StartContainer(ctx, &pb.ContainerRequest{ContainerName: "homeassistant"})
+	assert.Nil(t, err)
+
+	out, err := client.ExecContainer(ctx, &pb.ExecRequest{
+		ContainerName: "homeassistant",
+		Command:       []string{"sh", "-c", "echo $GREETING"},
+		Env:           map[string]string{"GREETING": "hello"},
+	})
+	assert.Nil(t, err)
+
+	var stdout, stderr string
+	var last *pb.ExecOutput
+	for {
+		o, err := out.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		if o.Stream == pb.OutputStream_STDERR {
+			stderr += string(o.Data)
+		} else {
+			stdout += string(o.Data)
+		}
+		last = o
+	}
+
+	assert.Equal(t, "GREETING=hello\n", stdout)
+	assert.Equal(t, "failed\n", stderr)
+	assert.True(t, last.Exited)
+	assert.Equal(t, int32(3), last.ExitCode)
+}
+
+func TestFakeStreamLogs(t *testing.T) {
+	ctx := context.Background()
+	fake := docker.NewFake()
+
+	client, closer := createRuntimeClient(ctx, fake)
+	defer closer()
+
+	_, err := client.StartContainer(ctx, &pb.ContainerRequest{ContainerName: "homeassistant"})
+	assert.Nil(t, err)
+	assert.Nil(t, fake.WriteLogs("homeassistant", "one\ntwo\nthree\n", ""))
+
+	out, err := client.StreamLogs(ctx, &pb.LogsRequest{ContainerName: "homeassistant", Tail: 2})
+	assert.Nil(t, err)
+
+	var logs string
+	for {
+		o, err := out.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		logs += string(o.Data)
+	}
+
+	assert.Equal(t, "two\nthree\n", logs)
+}
+
+func TestFakeListContainers(t *testing.T) {
+	ctx := context.Background()
+	fake := docker.NewFake()
+
+	client, closer := createRuntimeClient(ctx, fake)
+	defer closer()
+
+	for _, name := range []string{"mosquitto-a", "mosquitto-b", "zigbee2mqtt"} {
+		_, err := client.StartContainer(ctx, &pb.ContainerRequest{
+			ContainerName: name,
+			Image:         "eclipse-mosquitto",
+			Labels:        map[string]string{"ha-utils.test": "true"},
+		})
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, fake.Exit("mosquitto-b", 1))
+
+	out, err := client.ListContainers(ctx, &pb.ListContainersRequest{
+		Labels:      []string{"ha-utils.test=true"},
+		NamePattern: "mosquitto-*",
+		States:      []string{"running"},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, out.Containers, 1)
+	assert.Equal(t, "mosquitto-a", out.Containers[0].ContainerName)
+
+	out, err = client.ListContainers(ctx, &pb.ListContainersRequest{States: []string{"exited"}})
+	assert.Nil(t, err)
+	assert.Len(t, out.Containers, 1)
+	assert.Equal(t, "mosquitto-b", out.Containers[0].ContainerName)
+
+	_, err = client.ListContainers(ctx, &pb.ListContainersRequest{States: []string{"sleeping"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestFakeWatchContainers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	fake := docker.NewFake()
+
+	client, closer := createRuntimeClient(ctx, fake)
+	defer closer()
+
+	out, err := client.WatchContainers(ctx, &pb.WatchRequest{
+		ContainerNames: []string{"homeassistant"},
+		Events:         []pb.ContainerEventType{pb.ContainerEventType_EVENT_START, pb.ContainerEventType_EVENT_DIE},
+	})
+	assert.Nil(t, err)
+
+	// Give the server some time to subscribe to the events before starting the containers
+	time.Sleep(100 * time.Millisecond)
+
+	client.StartContainer(ctx, &pb.ContainerRequest{ContainerName: "mosquitto", Image: "eclipse-mosquitto"})
+	client.StartContainer(ctx, &pb.ContainerRequest{ContainerName: "homeassistant"})
+	client.RestartContainer(ctx, &pb.ContainerRequest{ContainerName: "homeassistant"})
+	fake.Exit("homeassistant", 137)
+
+	started, err := out.Recv()
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, pb.ContainerEventType_EVENT_START, started.Type)
+	assert.Equal(t, "homeassistant", started.ContainerName)
+
+	died, err := out.Recv()
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, pb.ContainerEventType_EVENT_DIE, died.Type)
+	assert.Equal(t, int32(137), died.ExitCode)
+}
+
+func TestFakeUpgradeContainer(t *testing.T) {
+	ctx := context.Background()
+	fake := docker.NewFake()
+
+	client, closer := createRuntimeClient(ctx, fake)
+	defer closer()
+
+	started, err := client.StartContainer(ctx, &pb.ContainerRequest{
+		ContainerName: "mosquitto",
+		Image:         "eclipse-mosquitto",
+		Tag:           "2.0.17",
+	})
+	assert.Nil(t, err)
+
+	plan, err := client.UpgradeContainer(ctx, &pb.UpgradeRequest{ContainerName: "mosquitto", Tag: "2.0.18", DryRun: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "eclipse-mosquitto:2.0.18", plan.Plan.Image)
+	assert.False(t, plan.Plan.ImagePresent)
+
+	out, err := client.UpgradeContainer(ctx, &pb.UpgradeRequest{ContainerName: "mosquitto", Tag: "2.0.18"})
+	assert.Nil(t, err)
+	assert.Equal(t, "eclipse-mosquitto:2.0.18", out.Image)
+	assert.Equal(t, "mosquitto", out.ContainerName)
+	assert.NotEqual(t, started.ContainerId, out.ContainerId)
+
+	// The tag can't be added to an image that already has one, like when starting the container
+	request := &pb.UpgradeRequest{ContainerName: "mosquitto", Image: "eclipse-mosquitto:2.0.18", Tag: "2.0.19"}
+	_, err = client.UpgradeContainer(ctx, request)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	request.DryRun = true
+	_, err = client.UpgradeContainer(ctx, request)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.StartContainer(ctx, &pb.ContainerRequest{
+		ContainerName: "mqtt",
+		Image:         "eclipse-mosquitto:2.0.18",
+		Tag:           "2.0.19",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestFakeApplyStack(t *testing.T) {
+	ctx := context.Background()
+	fake := docker.NewFake()
+
+	client, closer := createRuntimeClient(ctx, fake)
+	defer closer()
+
+	spec := `
+name: home
+networks: [home]
+volumes: [mosquitto-data]
+services:
+  - name: mosquitto
+    image: eclipse-mosquitto
+    networkMode: home
+    mounts:
+      - source: mosquitto-data
+        target: /mosquitto/data
+`
+
+	applyStack := func(dryRun bool) *pb.StackPlan {
+		out, err := client.ApplyStack(ctx, &pb.ApplyStackRequest{
+			Spec:   &pb.ApplyStackRequest_Yaml{Yaml: spec},
+			DryRun: dryRun,
+		})
+		assert.Nil(t, err)
+
+		var plan *pb.StackPlan
+		for {
+			update, err := out.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+
+			if update.GetPlan() != nil {
+				plan = update.GetPlan()
+			} else {
+				assert.Empty(t, update.GetResult().Error)
+			}
+		}
+
+		return plan
+	}
+
+	plan := applyStack(true)
+	assert.Len(t, plan.Steps, 3)
+	for _, step := range plan.Steps {
+		assert.Equal(t, pb.StackAction_STACK_CREATE, step.Action)
+	}
+
+	exists, _ := fake.NetworkExists(ctx, "home")
+	assert.False(t, exists)
+
+	applyStack(false)
+	exists, _ = fake.NetworkExists(ctx, "home")
+	assert.True(t, exists)
+
+	info, err := fake.GetContainer(ctx, "home-mosquitto")
+	assert.Nil(t, err)
+	assert.Equal(t, "home", info.Config.Labels[docker.StackLabel])
+
+	plan = applyStack(false)
+	for _, step := range plan.Steps {
+		assert.Equal(t, pb.StackAction_STACK_UNCHANGED, step.Action)
+	}
+}
+
+func TestFakeApplyProtoStack(t *testing.T) {
+	ctx := context.Background()
+	fake := docker.NewFake()
+
+	client, closer := createRuntimeClient(ctx, fake)
+	defer closer()
+
+	applyStack := func(stack *pb.Stack) error {
+		out, err := client.ApplyStack(ctx, &pb.ApplyStackRequest{Spec: &pb.ApplyStackRequest_Stack{Stack: stack}})
+		assert.Nil(t, err)
+
+		for {
+			_, err := out.Recv()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Like in a YAML stack, the services don't get the default image
+	err := applyStack(&pb.Stack{
+		Name:     "home",
+		Services: []*pb.StackService{{Name: "homeassistant"}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.ErrorContains(t, err, "service homeassistant is missing its image")
+
+	err = applyStack(&pb.Stack{
+		Name: "home",
+		Services: []*pb.StackService{{
+			Name:      "mosquitto",
+			Container: &pb.ContainerRequest{Image: "eclipse-mosquitto"},
+		}},
+	})
+	assert.Nil(t, err)
+
+	info, err := fake.GetContainer(ctx, "home-mosquitto")
+	assert.Nil(t, err)
+	assert.Empty(t, info.Config.Env)
+}
+
+func TestFakePodmanRuntime(t *testing.T) {
+	ctx := context.Background()
+	fake := docker.NewFake()
+	fake.SetInfo(docker.RuntimeInfo{Backend: docker.BackendPodman, Version: "4.7.2", Rootless: true})
+
+	client, closer := createRuntimeClient(ctx, fake)
+	defer closer()
+
+	info, err := client.GetRuntimeInfo(ctx, &pb.RuntimeInfoRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, pb.RuntimeBackend_BACKEND_PODMAN, info.Backend)
+	assert.Equal(t, "4.7.2", info.Version)
+	assert.True(t, info.Rootless)
+
+	_, err = client.StartContainer(ctx, &pb.ContainerRequest{ContainerName: "homeassistant"})
+	assert.Nil(t, err)
+	_, err = client.StartContainer(ctx, &pb.ContainerRequest{
+		ContainerName: "mosquitto",
+		Image:         "eclipse-mosquitto",
+		RestartPolicy: "on-failure:3",
+		NetworkMode:   "bridge",
+	})
+	assert.Nil(t, err)
+
+	homeassistant, err := fake.GetContainer(ctx, "homeassistant")
+	assert.Nil(t, err)
+	assert.Equal(t, "podman", string(homeassistant.HostConfig.NetworkMode))
+	assert.Equal(t, "always", homeassistant.HostConfig.RestartPolicy.Name)
+
+	mosquitto, err := fake.GetContainer(ctx, "mosquitto")
+	assert.Nil(t, err)
+	assert.Equal(t, "podman", string(mosquitto.HostConfig.NetworkMode))
+	assert.Equal(t, "on-failure", mosquitto.HostConfig.RestartPolicy.Name)
+	assert.Equal(t, 3, mosquitto.HostConfig.RestartPolicy.MaximumRetryCount)
+}
+
+func TestFakeImages(t *testing.T) {
+	ctx := context.Background()
+	fake := docker.NewFake()
+
+	client, closer := createRuntimeClient(ctx, fake)
+	defer closer()
+
+	for _, tag := range []string{"2023.9", "2023.10", "2023.11", "2023.12"} {
+		fake.AddImage("homeassistant/home-assistant:" + tag)
+	}
+	fake.AddImage("eclipse-mosquitto:2")
+
+	// The oldest image is still used, so it's kept along with the newest ones
+	_, err := client.StartContainer(ctx, &pb.ContainerRequest{ContainerName: "homeassistant", Tag: "2023.9"})
+	assert.Nil(t, err)
+
+	pull, err := client.PullImage(ctx, &pb.PullImageRequest{Image: "homeassistant/home-assistant", Tag: "2024.1"})
+	assert.Nil(t, err)
+
+	var progress []*pb.PullProgress
+	for {
+		p, err := pull.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		progress = append(progress, p)
+	}
+	assert.NotEmpty(t, progress)
+	assert.Contains(t, progress[len(progress)-1].Status, "Digest")
+
+	// The tag can't be added to an image that already has one
+	pull, err = client.PullImage(ctx, &pb.PullImageRequest{Image: "homeassistant/home-assistant:2024.1", Tag: "2024.2"})
+	assert.Nil(t, err)
+	_, err = pull.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	list, err := client.ListImages(ctx, &pb.ListImagesRequest{Repository: "homeassistant/home-assistant"})
+	assert.Nil(t, err)
+	assert.Len(t, list.Images, 5)
+	assert.Equal(t, []string{"homeassistant/home-assistant:2024.1"}, list.Images[0].Tags)
+	assert.Equal(t, int64(1), list.Images[4].Containers)
+
+	plan, err := client.PruneImages(ctx, &pb.PruneImagesRequest{DryRun: true})
+	assert.Nil(t, err)
+	assert.True(t, plan.DryRun)
+	assert.Len(t, plan.Removed, 2)
+
+	list, _ = client.ListImages(ctx, &pb.ListImagesRequest{})
+	assert.Len(t, list.Images, 6)
+
+	pruned, err := client.PruneImages(ctx, &pb.PruneImagesRequest{KeepLast: 1})
+	assert.Nil(t, err)
+	assert.Len(t, pruned.Removed, 3)
+	assert.Equal(t, int64(3*100<<20), pruned.SpaceReclaimed)
+
+	list, _ = client.ListImages(ctx, &pb.ListImagesRequest{})
+	var tags []string
+	for _, image := range list.Images {
+		tags = append(tags, image.Tags...)
+	}
+	assert.ElementsMatch(t, []string{
+		"homeassistant/home-assistant:2024.1",
+		"homeassistant/home-assistant:2023.9",
+		"eclipse-mosquitto:2",
+	}, tags)
+
+	_, err = client.PruneImages(ctx, &pb.PruneImagesRequest{KeepLast: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestFakeContainerDefaults(t *testing.T) {
+	ctx := context.Background()
+	fake := docker.NewFake()
+
+	client, closer := createRuntimeClient(ctx, fake, server.WithContainerDefaults(server.ContainerDefaults{
+		Image:         "ghcr.io/home-assistant/home-assistant",
+		Tag:           "stable",
+		RestartPolicy: "always",
+		Timezone:      "Europe/Madrid",
+	}))
+	defer closer()
+
+	_, err := client.StartContainer(ctx, &pb.ContainerRequest{ContainerName: "homeassistant"})
+	assert.Nil(t, err)
+	_, err = client.StartContainer(ctx, &pb.ContainerRequest{
+		ContainerName: "mosquitto",
+		Image:         "eclipse-mosquitto",
+		RestartPolicy: "no",
+		Env:           map[string]string{"TZ": "UTC"},
+	})
+	assert.Nil(t, err)
+
+	homeassistant, err := fake.GetContainer(ctx, "homeassistant")
+	assert.Nil(t, err)
+	assert.Equal(t, "ghcr.io/home-assistant/home-assistant:stable", homeassistant.Config.Image)
+	assert.Contains(t, homeassistant.Config.Env, "TZ=Europe/Madrid")
+	assert.Equal(t, "always", homeassistant.HostConfig.RestartPolicy.Name)
+
+	mosquitto, err := fake.GetContainer(ctx, "mosquitto")
+	assert.Nil(t, err)
+	assert.Equal(t, "eclipse-mosquitto", mosquitto.Config.Image)
+	assert.Equal(t, []string{"TZ=UTC"}, mosquitto.Config.Env)
+	assert.Equal(t, "no", mosquitto.HostConfig.RestartPolicy.Name)
+}
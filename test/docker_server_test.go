@@ -2,24 +2,35 @@ package test
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log"
 	"net"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aacuadras/ha-utils/lib/docker"
 	"github.com/aacuadras/ha-utils/server"
 	"github.com/aacuadras/ha-utils/server/pb"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 )
 
 func createClient(ctx context.Context) (pb.DockerUtilsClient, func()) {
+	return createRuntimeClient(ctx, docker.NewEngine())
+}
+
+func createRuntimeClient(ctx context.Context, rt docker.Runtime, opts ...server.DockerOption) (pb.DockerUtilsClient, func()) {
 	buffer := 1024 * 1024
 	listener := bufconn.Listen(buffer)
 
 	s := grpc.NewServer()
-	pb.RegisterDockerUtilsServer(s, server.NewServer())
+	pb.RegisterDockerUtilsServer(s, server.NewServer(rt, opts...))
 	go func() {
 		if err := s.Serve(listener); err != nil {
 			log.Fatalf("error listening: %v", err)
@@ -104,3 +115,457 @@ func TestGetEmptyContainerCall(t *testing.T) {
 	assert.Empty(t, out.ContainerId)
 	assert.Empty(t, out.Status)
 }
+
+func TestStartCustomContainerCall(t *testing.T) {
+	ctx := context.Background()
+
+	client, closer := createClient(ctx)
+	defer closer()
+
+	request := pb.ContainerRequest{
+		ContainerName: "mosquitto-test",
+		Image:         "eclipse-mosquitto",
+		Tag:           "2",
+		Env: map[string]string{
+			"TZ": "UTC",
+		},
+		Ports: []*pb.PortMapping{
+			{HostPort: 18830, ContainerPort: 1883},
+		},
+		Mounts: []*pb.Mount{
+			{Source: "mosquitto-test-data", Target: "/mosquitto/data"},
+		},
+		RestartPolicy: "on-failure",
+		Labels: map[string]string{
+			"ha-utils.test": "true",
+		},
+	}
+
+	out, err := client.StartContainer(ctx, &request)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, out.ContainerId)
+	defer client.StopContainer(ctx, &request)
+
+	info, err := docker.NewEngine().GetContainer(ctx, out.ContainerId)
+	assert.Nil(t, err)
+	assert.Equal(t, "eclipse-mosquitto:2", info.Config.Image)
+	assert.Contains(t, info.Config.Env, "TZ=UTC")
+	assert.Equal(t, "true", info.Config.Labels["ha-utils.test"])
+	assert.Equal(t, "on-failure", string(info.HostConfig.RestartPolicy.Name))
+	assert.Equal(t, "18830", info.HostConfig.PortBindings["1883/tcp"][0].HostPort)
+}
+
+func TestExecContainerCall(t *testing.T) {
+	ctx := context.Background()
+
+	client, closer := createClient(ctx)
+	defer closer()
+
+	request := pb.ContainerRequest{
+		ContainerName: "container-test",
+	}
+
+	client.StartContainer(ctx, &request)
+	defer client.StopContainer(ctx, &request)
+
+	out, err := client.ExecContainer(ctx, &pb.ExecRequest{
+		ContainerName: request.ContainerName,
+		Command:       []string{"sh", "-c", "echo $GREETING; echo failed >&2; exit 3"},
+		Env: map[string]string{
+			"GREETING": "hello",
+		},
+	})
+	assert.Nil(t, err)
+
+	var stdout, stderr string
+	var last *pb.ExecOutput
+	for {
+		o, err := out.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		if o.Stream == pb.OutputStream_STDERR {
+			stderr += string(o.Data)
+		} else {
+			stdout += string(o.Data)
+		}
+		last = o
+	}
+
+	assert.Equal(t, "hello\n", stdout)
+	assert.Equal(t, "failed\n", stderr)
+	assert.True(t, last.Exited)
+	assert.Equal(t, int32(3), last.ExitCode)
+}
+
+func TestStreamLogsCall(t *testing.T) {
+	ctx := context.Background()
+
+	client, closer := createClient(ctx)
+	defer closer()
+
+	request := pb.ContainerRequest{
+		ContainerName: "container-test",
+	}
+
+	client.StartContainer(ctx, &request)
+	defer client.StopContainer(ctx, &request)
+
+	// Give the container some time to write its startup logs
+	time.Sleep(5 * time.Second)
+
+	out, err := client.StreamLogs(ctx, &pb.LogsRequest{
+		ContainerName: request.ContainerName,
+		Tail:          10,
+		Timestamps:    true,
+	})
+	assert.Nil(t, err)
+
+	var chunks []*pb.LogOutput
+	for {
+		o, err := out.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		chunks = append(chunks, o)
+	}
+
+	assert.NotEmpty(t, chunks)
+}
+
+func TestRestartContainerCall(t *testing.T) {
+	ctx := context.Background()
+
+	client, closer := createClient(ctx)
+	defer closer()
+
+	request := pb.ContainerRequest{
+		ContainerName: "container-test",
+	}
+
+	started, err := client.StartContainer(ctx, &request)
+	assert.Nil(t, err)
+	defer client.StopContainer(ctx, &request)
+
+	out, err := client.RestartContainer(ctx, &request)
+	assert.Nil(t, err)
+	assert.Equal(t, started.ContainerId, out.ContainerId)
+	assert.Equal(t, "running", out.Status)
+}
+
+func TestUpgradeContainerCall(t *testing.T) {
+	ctx := context.Background()
+
+	client, closer := createClient(ctx)
+	defer closer()
+
+	request := pb.ContainerRequest{
+		ContainerName: "mosquitto-test",
+		Image:         "eclipse-mosquitto",
+		Tag:           "2.0.17",
+		Mounts: []*pb.Mount{
+			{Source: "mosquitto-test-data", Target: "/mosquitto/data"},
+		},
+	}
+
+	started, err := client.StartContainer(ctx, &request)
+	assert.Nil(t, err)
+	defer client.StopContainer(ctx, &request)
+
+	out, err := client.UpgradeContainer(ctx, &pb.UpgradeRequest{
+		ContainerName:        request.ContainerName,
+		Tag:                  "2.0.18",
+		HealthTimeoutSeconds: 30,
+	})
+	assert.Nil(t, err)
+	assert.NotEqual(t, started.ContainerId, out.ContainerId)
+	assert.Equal(t, "mosquitto-test", out.ContainerName)
+	assert.Equal(t, "eclipse-mosquitto:2.0.18", out.Image)
+	assert.Equal(t, "running", out.Status)
+
+	info, err := docker.NewEngine().GetContainer(ctx, out.ContainerId)
+	assert.Nil(t, err)
+	assert.Equal(t, "mosquitto-test-data", info.HostConfig.Mounts[0].Source)
+}
+
+func TestStartContainerReadinessCall(t *testing.T) {
+	ctx := context.Background()
+
+	client, closer := createClient(ctx)
+	defer closer()
+
+	request := pb.ContainerRequest{
+		ContainerName: "container-test",
+		Readiness: &pb.Readiness{
+			Mode:           pb.ReadinessMode_READINESS_HTTP,
+			TimeoutSeconds: 120,
+		},
+	}
+
+	out, err := client.StartContainer(ctx, &request)
+	assert.Nil(t, err)
+	defer client.StopContainer(ctx, &request)
+	assert.Equal(t, "running", out.Status)
+}
+
+func TestStartContainerNotReadyCall(t *testing.T) {
+	ctx := context.Background()
+
+	client, closer := createClient(ctx)
+	defer closer()
+
+	request := pb.ContainerRequest{
+		ContainerName: "not-ready-test",
+		Image:         "alpine",
+		Tag:           "3",
+		RestartPolicy: "no",
+		// The default shell of alpine exits right away without a terminal
+		Readiness: &pb.Readiness{TimeoutSeconds: 30},
+	}
+
+	_, err := client.StartContainer(ctx, &request)
+	defer client.StopContainer(ctx, &request)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Contains(t, err.Error(), "exited with code 0")
+}
+
+func TestListContainersCall(t *testing.T) {
+	ctx := context.Background()
+
+	client, closer := createClient(ctx)
+	defer closer()
+
+	request := pb.ContainerRequest{
+		ContainerName: "mosquitto-test",
+		Image:         "eclipse-mosquitto",
+		Tag:           "2",
+		Ports: []*pb.PortMapping{
+			{HostPort: 18830, ContainerPort: 1883},
+		},
+		Labels: map[string]string{
+			"ha-utils.test": "true",
+		},
+	}
+
+	_, err := client.StartContainer(ctx, &request)
+	assert.Nil(t, err)
+	defer client.StopContainer(ctx, &request)
+
+	out, err := client.ListContainers(ctx, &pb.ListContainersRequest{
+		Labels:      []string{"ha-utils.test=true"},
+		NamePattern: "mosquitto-*",
+		States:      []string{"running"},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, out.Containers, 1)
+	assert.Equal(t, "mosquitto-test", out.Containers[0].ContainerName)
+	assert.Equal(t, "eclipse-mosquitto:2", out.Containers[0].Image)
+	assert.Equal(t, "running", out.Containers[0].State)
+	assert.NotNil(t, out.Containers[0].CreatedAt)
+	assert.Equal(t, "true", out.Containers[0].Labels["ha-utils.test"])
+
+	out, err = client.ListContainers(ctx, &pb.ListContainersRequest{
+		Labels: []string{"ha-utils.test=false"},
+	})
+	assert.Nil(t, err)
+	assert.Empty(t, out.Containers)
+
+	_, err = client.ListContainers(ctx, &pb.ListContainersRequest{States: []string{"sleeping"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestWatchContainersCall(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	client, closer := createClient(ctx)
+	defer closer()
+
+	request := pb.ContainerRequest{
+		ContainerName: "container-test",
+	}
+
+	watchCtx, stopWatching := context.WithCancel(ctx)
+	defer stopWatching()
+
+	out, err := client.WatchContainers(watchCtx, &pb.WatchRequest{
+		ContainerNames: []string{request.ContainerName},
+		Events:         []pb.ContainerEventType{pb.ContainerEventType_EVENT_START, pb.ContainerEventType_EVENT_DIE},
+	})
+	assert.Nil(t, err)
+
+	// Give the server some time to subscribe to the events before starting the container
+	time.Sleep(time.Second)
+
+	client.StartContainer(ctx, &request)
+	client.StopContainer(ctx, &request)
+
+	started, err := out.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, pb.ContainerEventType_EVENT_START, started.Type)
+	assert.Equal(t, request.ContainerName, started.ContainerName)
+
+	died, err := out.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, pb.ContainerEventType_EVENT_DIE, died.Type)
+	assert.NotNil(t, died.Time)
+}
+
+func TestGetContainerStatsCall(t *testing.T) {
+	ctx := context.Background()
+
+	client, closer := createClient(ctx)
+	defer closer()
+
+	request := pb.ContainerRequest{
+		ContainerName: "container-test",
+	}
+
+	client.StartContainer(ctx, &request)
+	defer client.StopContainer(ctx, &request)
+
+	out, err := client.GetContainerStats(ctx, &pb.StatsRequest{ContainerName: request.ContainerName})
+	assert.Nil(t, err)
+	assert.Equal(t, request.ContainerName, out.ContainerName)
+	assert.NotZero(t, out.MemoryUsage)
+	assert.NotZero(t, out.MemoryLimit)
+	assert.NotZero(t, out.Pids)
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.StreamContainerStats(streamCtx, &pb.StatsRequest{ContainerName: request.ContainerName})
+	assert.Nil(t, err)
+
+	for i := 0; i < 2; i++ {
+		sample, err := stream.Recv()
+		assert.Nil(t, err)
+		assert.Equal(t, request.ContainerName, sample.ContainerName)
+	}
+}
+
+func TestApplyStackCall(t *testing.T) {
+	ctx := context.Background()
+
+	client, closer := createClient(ctx)
+	defer closer()
+
+	spec := `
+name: ha-utils-test
+volumes: [ha-utils-test-data]
+services:
+  - name: mosquitto
+    image: eclipse-mosquitto
+    tag: "2"
+    mounts:
+      - source: ha-utils-test-data
+        target: /mosquitto/data
+`
+	defer client.StopContainer(ctx, &pb.ContainerRequest{ContainerName: "ha-utils-test-mosquitto"})
+
+	applyStack := func(spec string, dryRun bool) (*pb.StackPlan, []*pb.StackStepResult) {
+		out, err := client.ApplyStack(ctx, &pb.ApplyStackRequest{
+			Spec:   &pb.ApplyStackRequest_Yaml{Yaml: spec},
+			DryRun: dryRun,
+		})
+		assert.Nil(t, err)
+
+		var plan *pb.StackPlan
+		var results []*pb.StackStepResult
+		for {
+			update, err := out.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if !assert.Nil(t, err) {
+				t.FailNow()
+			}
+
+			if update.GetPlan() != nil {
+				plan = update.GetPlan()
+			} else {
+				results = append(results, update.GetResult())
+			}
+		}
+
+		return plan, results
+	}
+
+	plan, results := applyStack(spec, true)
+	assert.Len(t, plan.Steps, 2)
+	assert.Equal(t, pb.StackAction_STACK_CREATE, plan.Steps[1].Action)
+	assert.Empty(t, results)
+
+	plan, results = applyStack(spec, false)
+	assert.Len(t, results, len(plan.Steps))
+	for _, result := range results {
+		assert.Empty(t, result.Error)
+	}
+
+	plan, _ = applyStack(spec, false)
+	assert.Equal(t, pb.StackAction_STACK_UNCHANGED, plan.Steps[1].Action)
+
+	plan, _ = applyStack(strings.Replace(spec, `tag: "2"`, `tag: "2.0.18"`, 1), false)
+	assert.Equal(t, pb.StackAction_STACK_RECREATE, plan.Steps[1].Action)
+	assert.Equal(t, "settings changed", plan.Steps[1].Reason)
+}
+
+func TestStartContainerDryRunCall(t *testing.T) {
+	ctx := context.Background()
+
+	client, closer := createClient(ctx)
+	defer closer()
+
+	request := pb.ContainerRequest{
+		ContainerName: "dry-run-test",
+		Image:         "eclipse-mosquitto",
+		Tag:           "2",
+		DryRun:        true,
+	}
+
+	out, err := client.StartContainer(ctx, &request)
+	assert.Nil(t, err)
+	assert.Equal(t, "create", out.Plan.Action)
+	assert.Equal(t, "eclipse-mosquitto:2", out.Plan.Image)
+	assert.Empty(t, out.ContainerId)
+
+	ids, err := docker.NewEngine().ListContainers(ctx, docker.ListOptions{Name: request.ContainerName})
+	assert.Nil(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestPullImageCall(t *testing.T) {
+	ctx := context.Background()
+
+	client, closer := createClient(ctx)
+	defer closer()
+
+	out, err := client.PullImage(ctx, &pb.PullImageRequest{Image: "alpine", Tag: "3.18"})
+	assert.Nil(t, err)
+
+	var progress []*pb.PullProgress
+	for {
+		p, err := out.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		progress = append(progress, p)
+	}
+	assert.NotEmpty(t, progress)
+
+	list, err := client.ListImages(ctx, &pb.ListImagesRequest{Repository: "alpine"})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, list.Images)
+}
@@ -2,9 +2,16 @@ package test
 
 import (
 	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/aacuadras/ha-utils/lib/docker"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,15 +24,329 @@ func TestCreateDestroyDockerContainer(t *testing.T) {
 		},
 	}
 
-	id, _ := docker.StartContainer(containerSettings, context.Background())
+	engine := docker.NewEngine()
+	id, _ := engine.StartContainer(context.Background(), containerSettings)
 
-	containerIds, err := docker.ListContainerIDs(context.Background())
+	containerIds, err := engine.ListContainerIDs(context.Background())
 
 	assert.Nil(t, err)
 	assert.Contains(t, containerIds, id)
 
-	docker.StopContainer(containerSettings)
-	containerIds, _ = docker.ListContainerIDs(context.Background())
+	engine.StopContainer(context.Background(), containerSettings.ContainerName)
+	containerIds, _ = engine.ListContainerIDs(context.Background())
 
 	assert.NotContains(t, containerIds, id)
 }
+
+func TestValidateSettings(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	assert.Nil(t, os.Mkdir(filepath.Join(root, "homeassistant"), 0700))
+	assert.Nil(t, os.Symlink(outside, filepath.Join(root, "escape")))
+	assert.Nil(t, os.Symlink(filepath.Join(outside, "missing"), filepath.Join(root, "dangling")))
+
+	testCases := map[string]struct {
+		settings *docker.Settings
+		root     string
+		err      error
+	}{
+		"config_dir_inside_root": {
+			settings: &docker.Settings{ConfigDir: filepath.Join(root, "homeassistant")},
+			root:     root,
+		},
+		"missing_config_dir_inside_root": {
+			settings: &docker.Settings{ConfigDir: filepath.Join(root, "zigbee2mqtt", "data")},
+			root:     root,
+		},
+		"config_dir_outside_root": {
+			settings: &docker.Settings{ConfigDir: outside},
+			root:     root,
+			err:      docker.ErrPathNotAllowed,
+		},
+		"bind_mount_traversal": {
+			settings: &docker.Settings{Mounts: []docker.Mount{
+				{Source: filepath.Join(root, "..", filepath.Base(outside)), Target: "/data"},
+			}},
+			root: root,
+			err:  docker.ErrPathNotAllowed,
+		},
+		"bind_mount_symlink_outside_root": {
+			settings: &docker.Settings{Mounts: []docker.Mount{
+				{Type: "bind", Source: filepath.Join(root, "escape", "data"), Target: "/data"},
+			}},
+			root: root,
+			err:  docker.ErrPathNotAllowed,
+		},
+		"bind_mount_dangling_symlink_outside_root": {
+			settings: &docker.Settings{Mounts: []docker.Mount{
+				{Type: "bind", Source: filepath.Join(root, "dangling", "data"), Target: "/data"},
+			}},
+			root: root,
+			err:  docker.ErrPathNotAllowed,
+		},
+		"bind_mount_without_root": {
+			settings: &docker.Settings{ConfigDir: filepath.Join(root, "homeassistant")},
+			err:      docker.ErrPathNotAllowed,
+		},
+		"relative_bind_mount": {
+			settings: &docker.Settings{Mounts: []docker.Mount{
+				{Type: "bind", Source: "homeassistant", Target: "/config"},
+			}},
+			root: root,
+			err:  docker.ErrInvalidSettings,
+		},
+		"volume_without_root": {
+			settings: &docker.Settings{Mounts: []docker.Mount{
+				{Source: "mosquitto-data", Target: "/mosquitto/data"},
+			}},
+		},
+		"tagged_image": {
+			settings: &docker.Settings{ImageName: "eclipse-mosquitto:2", Tag: "2.0.18"},
+			err:      docker.ErrInvalidSettings,
+		},
+		"device": {
+			settings: &docker.Settings{Devices: []docker.Device{{HostPath: "/dev/ttyUSB0"}}},
+		},
+		"device_outside_dev": {
+			settings: &docker.Settings{Devices: []docker.Device{{HostPath: "/etc/shadow"}}},
+			err:      docker.ErrPathNotAllowed,
+		},
+		"http_readiness_on_published_port": {
+			settings: &docker.Settings{
+				Ports:     []docker.PortMapping{{HostPort: 18123, ContainerPort: 8123}},
+				Readiness: &docker.Readiness{Mode: docker.ReadinessHTTP},
+			},
+		},
+		"http_readiness_in_host_network": {
+			settings: &docker.Settings{
+				NetworkMode: "host",
+				Readiness:   &docker.Readiness{Mode: docker.ReadinessHTTP},
+			},
+		},
+		"http_readiness_without_port": {
+			settings: &docker.Settings{Readiness: &docker.Readiness{Mode: docker.ReadinessHTTP}},
+			err:      docker.ErrInvalidSettings,
+		},
+		"http_readiness_invalid_url": {
+			settings: &docker.Settings{Readiness: &docker.Readiness{Mode: docker.ReadinessHTTP, URL: "localhost:8123"}},
+			err:      docker.ErrInvalidSettings,
+		},
+		"restart_policy_with_retries": {
+			settings: &docker.Settings{RestartPolicy: "on-failure:3"},
+		},
+		"invalid_restart_retries": {
+			settings: &docker.Settings{RestartPolicy: "on-failure:many"},
+			err:      docker.ErrInvalidSettings,
+		},
+		"unknown_restart_policy": {
+			settings: &docker.Settings{RestartPolicy: "sometimes"},
+			err:      docker.ErrInvalidSettings,
+		},
+		"unknown_readiness_mode": {
+			settings: &docker.Settings{Readiness: &docker.Readiness{Mode: "tcp"}},
+			err:      docker.ErrInvalidSettings,
+		},
+	}
+
+	for scenario, testcase := range testCases {
+		t.Run(scenario, func(t *testing.T) {
+			err := docker.ValidateSettings(testcase.settings, testcase.root)
+
+			if testcase.err != nil {
+				assert.ErrorIs(t, err, testcase.err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestDetectSocket(t *testing.T) {
+	if _, err := os.Stat("/var/run/docker.sock"); err == nil {
+		t.Skip("the docker socket takes precedence over podman")
+	}
+
+	runtimeDir := t.TempDir()
+	t.Setenv("XDG_RUNTIME_DIR", runtimeDir)
+	assert.Equal(t, "", docker.DetectSocket())
+
+	socket := filepath.Join(runtimeDir, "podman", "podman.sock")
+	assert.Nil(t, os.Mkdir(filepath.Dir(socket), 0700))
+	listener, err := net.Listen("unix", socket)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer listener.Close()
+
+	assert.Equal(t, "unix://"+socket, docker.DetectSocket())
+}
+
+func TestTaggedImage(t *testing.T) {
+	testCases := map[string]struct {
+		image    string
+		tag      string
+		expected string
+		err      bool
+	}{
+		"no_tag":           {image: "alpine", expected: "alpine"},
+		"tag":              {image: "alpine", tag: "3.18", expected: "alpine:3.18"},
+		"registry_port":    {image: "localhost:5000/homeassistant", tag: "2024.1", expected: "localhost:5000/homeassistant:2024.1"},
+		"tagged_image":     {image: "alpine:3.18", expected: "alpine:3.18"},
+		"two_tags":         {image: "alpine:3.18", tag: "3.19", err: true},
+		"digest_and_tag":   {image: "alpine@sha256:" + strings.Repeat("a", 64), tag: "3.19", err: true},
+		"registry_and_tag": {image: "localhost:5000/homeassistant:2024.1", tag: "2024.2", err: true},
+	}
+
+	for scenario, testcase := range testCases {
+		t.Run(scenario, func(t *testing.T) {
+			image, err := docker.TaggedImage(testcase.image, testcase.tag)
+			if testcase.err {
+				assert.True(t, errdefs.IsInvalidParameter(err))
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.expected, image)
+		})
+	}
+}
+
+func TestCalculateStats(t *testing.T) {
+	sample := `{
+		"id": "abc123",
+		"name": "/homeassistant",
+		"read": "2023-11-04T10:00:00Z",
+		"cpu_stats": {
+			"cpu_usage": {"total_usage": 3000000000},
+			"system_cpu_usage": 20000000000,
+			"online_cpus": 4
+		},
+		"precpu_stats": {
+			"cpu_usage": {"total_usage": 2000000000},
+			"system_cpu_usage": 16000000000
+		},
+		"memory_stats": {
+			"usage": 524288000,
+			"limit": 1048576000,
+			"stats": {"inactive_file": 104857600}
+		},
+		"networks": {
+			"eth0": {"rx_bytes": 1000, "tx_bytes": 2000},
+			"eth1": {"rx_bytes": 500, "tx_bytes": 100}
+		},
+		"blkio_stats": {
+			"io_service_bytes_recursive": [
+				{"op": "read", "value": 4096},
+				{"op": "Write", "value": 8192},
+				{"op": "Total", "value": 12288}
+			]
+		},
+		"pids_stats": {"current": 42}
+	}`
+
+	var stats types.StatsJSON
+	assert.Nil(t, json.Unmarshal([]byte(sample), &stats))
+
+	calculated := docker.CalculateStats(stats)
+	assert.Equal(t, "abc123", calculated.ContainerID)
+	assert.Equal(t, "homeassistant", calculated.ContainerName)
+	assert.InDelta(t, 100.0, calculated.CPUPercent, 0.001)
+	assert.Equal(t, uint64(419430400), calculated.MemoryUsage)
+	assert.Equal(t, uint64(1048576000), calculated.MemoryLimit)
+	assert.InDelta(t, 40.0, calculated.MemoryPercent, 0.001)
+	assert.Equal(t, uint64(1500), calculated.NetworkRx)
+	assert.Equal(t, uint64(2100), calculated.NetworkTx)
+	assert.Equal(t, uint64(4096), calculated.BlockRead)
+	assert.Equal(t, uint64(8192), calculated.BlockWrite)
+	assert.Equal(t, uint64(42), calculated.PIDs)
+}
+
+func TestParseStack(t *testing.T) {
+	stack, err := docker.ParseStack([]byte(`
+name: home
+networks: [home]
+volumes: [mosquitto-data]
+services:
+  - name: mosquitto
+    image: eclipse-mosquitto
+    tag: "2"
+    networkMode: home
+    env:
+      TZ: UTC
+      LOG: debug
+    mounts:
+      - source: mosquitto-data
+        target: /mosquitto/data
+  - name: homeassistant
+    image: homeassistant/home-assistant
+    ports:
+      - containerPort: 8123
+    readiness:
+      mode: http
+      timeoutSeconds: 60
+`))
+	assert.Nil(t, err)
+	assert.Nil(t, docker.ValidateStack(stack, ""))
+
+	assert.Equal(t, "home", stack.Name)
+	assert.Equal(t, []string{"home"}, stack.Networks)
+	assert.Equal(t, []string{"mosquitto-data"}, stack.Volumes)
+	assert.Len(t, stack.Services, 2)
+
+	mosquitto := stack.Services[0].Settings
+	assert.Equal(t, "home-mosquitto", mosquitto.ContainerName)
+	assert.Equal(t, "eclipse-mosquitto:2", mosquitto.Image())
+	assert.Equal(t, []string{"LOG=debug", "TZ=UTC"}, mosquitto.EnvVars)
+	assert.Equal(t, "mosquitto-data", mosquitto.Mounts[0].Source)
+
+	homeassistant := stack.Services[1].Settings
+	assert.Equal(t, 8123, homeassistant.Ports[0].ContainerPort)
+	assert.Equal(t, docker.ReadinessHTTP, homeassistant.Readiness.Mode)
+}
+
+func TestValidateStack(t *testing.T) {
+	testCases := map[string]struct {
+		spec string
+		err  error
+	}{
+		"unknown_field": {
+			spec: "name: home\nservices:\n  - name: mqtt\n    img: eclipse-mosquitto\n",
+			err:  docker.ErrInvalidStack,
+		},
+		"missing_name": {
+			spec: "services:\n  - name: mqtt\n    image: eclipse-mosquitto\n",
+			err:  docker.ErrInvalidStack,
+		},
+		"no_services": {
+			spec: "name: home\n",
+			err:  docker.ErrInvalidStack,
+		},
+		"duplicated_service": {
+			spec: "name: home\nservices:\n  - name: mqtt\n    image: eclipse-mosquitto\n  - name: mqtt\n    image: eclipse-mosquitto\n",
+			err:  docker.ErrInvalidStack,
+		},
+		"missing_image": {
+			spec: "name: home\nservices:\n  - name: mqtt\n",
+			err:  docker.ErrInvalidStack,
+		},
+		"bind_mount_without_root": {
+			spec: "name: home\nservices:\n  - name: ha\n    image: homeassistant/home-assistant\n    configDir: /srv/ha\n",
+			err:  docker.ErrPathNotAllowed,
+		},
+		"empty_file": {
+			spec: "",
+			err:  docker.ErrInvalidStack,
+		},
+	}
+
+	for scenario, testcase := range testCases {
+		t.Run(scenario, func(t *testing.T) {
+			stack, err := docker.ParseStack([]byte(testcase.spec))
+			if err == nil {
+				err = docker.ValidateStack(stack, "")
+			}
+
+			assert.ErrorIs(t, err, testcase.err)
+		})
+	}
+}
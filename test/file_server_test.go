@@ -7,6 +7,8 @@ import (
 	"io"
 	"log"
 	"net"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/aacuadras/ha-utils/lib/filediff"
@@ -14,16 +16,18 @@ import (
 	"github.com/aacuadras/ha-utils/server/pb"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 )
 
-func createFileClient(ctx context.Context) (pb.FileUtilsClient, func()) {
+func createFileClient(ctx context.Context, opts ...server.FileOption) (pb.FileUtilsClient, func()) {
 	buffer := 1024 * 1024
 	listener := bufconn.Listen(buffer)
 
 	s := grpc.NewServer()
-	pb.RegisterFileUtilsServer(s, server.NewFileServer())
+	pb.RegisterFileUtilsServer(s, server.NewFileServer(opts...))
 	go func() {
 		if err := s.Serve(listener); err != nil {
 			log.Fatalf("error listening: %v", err)
@@ -57,7 +61,7 @@ func encondeFileContent(fileContent string) string {
 
 func TestCompareFile(t *testing.T) {
 	ctx := context.Background()
-	client, closer := createFileClient(ctx)
+	client, closer := createFileClient(ctx, server.WithBackups(t.TempDir(), 0))
 	defer closer()
 
 	type expectation struct {
@@ -88,6 +92,9 @@ func TestCompareFile(t *testing.T) {
 			expected: expectation{
 				output: &pb.FileDiff{
 					IsSame: false,
+					UnifiedDiff: "--- a/test_files/test.txt\n+++ b/test_files/test.txt\n" +
+						"@@ -1 +1 @@\n-This is a test\n\\ No newline at end of file\n" +
+						"+This is a test, but not the same one\n\\ No newline at end of file\n",
 				},
 			},
 		},
@@ -97,7 +104,11 @@ func TestCompareFile(t *testing.T) {
 				EncodedContent: encondeFileContent("Does it matter? The file doesn't exist"),
 			},
 			expected: expectation{
-				err: errors.New("no such file or directory"),
+				output: &pb.FileDiff{
+					IsSame: false,
+					UnifiedDiff: "--- a/test_files/nonexistenttest.txt\n+++ b/test_files/nonexistenttest.txt\n" +
+						"@@ -0,0 +1 @@\n+Does it matter? The file doesn't exist\n\\ No newline at end of file\n",
+				},
 			},
 		},
 		"test_non_base64": {
@@ -120,6 +131,7 @@ func TestCompareFile(t *testing.T) {
 				assert.ErrorContains(t, err, testcase.expected.err.Error())
 			} else {
 				assert.Equal(t, testcase.expected.output.IsSame, out.IsSame)
+				assert.Equal(t, testcase.expected.output.UnifiedDiff, out.UnifiedDiff)
 			}
 		})
 	}
@@ -127,7 +139,7 @@ func TestCompareFile(t *testing.T) {
 
 func TestSendFile(t *testing.T) {
 	ctx := context.Background()
-	client, closer := createFileClient(ctx)
+	client, closer := createFileClient(ctx, server.WithBackups(t.TempDir(), 0))
 	defer closer()
 
 	type expectation struct {
@@ -147,6 +159,61 @@ func TestSendFile(t *testing.T) {
 				output: &pb.ProcessedFile{
 					Processed: true,
 					FileName:  "./test_files/test.txt",
+					Action:    pb.FileAction_UPDATED,
+				},
+			},
+		},
+		"send_same_file": {
+			input: &pb.File{
+				FileName:       "./test_files/test.txt",
+				EncodedContent: encondeFileContent("This is a test"),
+			},
+			expected: expectation{
+				output: &pb.ProcessedFile{
+					Processed: false,
+					FileName:  "./test_files/test.txt",
+					Action:    pb.FileAction_UNCHANGED,
+				},
+			},
+		},
+		"send_new_file": {
+			input: &pb.File{
+				FileName:       "./test_files/packages/lights.yaml",
+				EncodedContent: encondeFileContent("light:\n  - platform: hue\n"),
+			},
+			expected: expectation{
+				output: &pb.ProcessedFile{
+					Processed: true,
+					FileName:  "./test_files/packages/lights.yaml",
+					Action:    pb.FileAction_CREATED,
+				},
+			},
+		},
+		"create_only_existing_file": {
+			input: &pb.File{
+				FileName:       "./test_files/test.txt",
+				EncodedContent: encondeFileContent("This is a different test"),
+				Mode:           pb.FileMode_CREATE_ONLY,
+			},
+			expected: expectation{
+				output: &pb.ProcessedFile{
+					Processed: false,
+					FileName:  "./test_files/test.txt",
+					Error:     "file already exists: ",
+				},
+			},
+		},
+		"replace_only_missing_file": {
+			input: &pb.File{
+				FileName:       "./test_files/missing.txt",
+				EncodedContent: encondeFileContent("This is a different test"),
+				Mode:           pb.FileMode_REPLACE_ONLY,
+			},
+			expected: expectation{
+				output: &pb.ProcessedFile{
+					Processed: false,
+					FileName:  "./test_files/missing.txt",
+					Error:     "file does not exist: ",
 				},
 			},
 		},
@@ -154,6 +221,7 @@ func TestSendFile(t *testing.T) {
 
 	for scenario, testcase := range testCases {
 		t.Run(scenario, func(t *testing.T) {
+			os.RemoveAll("./test_files")
 			filediff.CreateTestFile("This is a test", "test.txt")
 			out, err := client.SendFile(ctx, testcase.input)
 			log.Print(out.Processed)
@@ -162,14 +230,50 @@ func TestSendFile(t *testing.T) {
 			assert.Nil(t, err)
 			assert.Equal(t, testcase.expected.output.Processed, out.Processed)
 			assert.Equal(t, testcase.expected.output.FileName, out.FileName)
-			assert.Equal(t, testcase.expected.output.Error, out.Error)
+			assert.Equal(t, testcase.expected.output.Action, out.Action)
+			if testcase.expected.output.Error != "" {
+				assert.Contains(t, out.Error, testcase.expected.output.Error)
+			} else {
+				assert.Empty(t, out.Error)
+			}
+		})
+	}
+}
+
+func TestSendFileOutsideRoot(t *testing.T) {
+	ctx := context.Background()
+	client, closer := createFileClient(ctx, server.WithBackups(t.TempDir(), 0))
+	defer closer()
+
+	testCases := map[string]struct {
+		fileName string
+		code     codes.Code
+	}{
+		"parent_traversal": {
+			fileName: "../test_files/test.txt",
+			code:     codes.InvalidArgument,
+		},
+		"absolute_path": {
+			fileName: "/etc/hosts",
+			code:     codes.PermissionDenied,
+		},
+	}
+
+	for scenario, testcase := range testCases {
+		t.Run(scenario, func(t *testing.T) {
+			_, err := client.SendFile(ctx, &pb.File{
+				FileName:       testcase.fileName,
+				EncodedContent: encondeFileContent("This should never be written"),
+			})
+
+			assert.Equal(t, testcase.code, status.Code(err))
 		})
 	}
 }
 
 func TestCompareFiles(t *testing.T) {
 	ctx := context.Background()
-	client, closer := createFileClient(ctx)
+	client, closer := createFileClient(ctx, server.WithBackups(t.TempDir(), 0))
 	defer closer()
 
 	type expectation struct {
@@ -305,7 +409,7 @@ func TestCompareFiles(t *testing.T) {
 
 func TestSendFiles(t *testing.T) {
 	ctx := context.Background()
-	client, closer := createFileClient(ctx)
+	client, closer := createFileClient(ctx, server.WithBackups(t.TempDir(), 0))
 	defer closer()
 
 	testcases := map[string]struct {
@@ -370,9 +474,11 @@ func TestSendFiles(t *testing.T) {
 			expected: []*pb.ProcessedFile{
 				{
 					Processed: false,
+					FileName:  "./test_files/test1.txt",
 				},
 				{
 					Processed: false,
+					FileName:  "./test_files/test2.txt",
 				},
 			},
 		},
@@ -415,3 +521,404 @@ func TestSendFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestBackupAndRestoreFile(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	client, closer := createFileClient(ctx, server.WithFileRoot(root), server.WithBackups(t.TempDir(), 2))
+	defer closer()
+
+	versions := []string{"version: 1\n", "version: 2\n", "version: 3\n", "version: 4\n"}
+	for _, version := range versions {
+		_, err := client.SendFile(ctx, &pb.File{
+			FileName:       "configuration.yaml",
+			EncodedContent: encondeFileContent(version),
+		})
+		assert.Nil(t, err)
+	}
+
+	// The first version was created without a backup and the retention only keeps versions 2 and 3
+	list, err := client.ListBackups(ctx, &pb.BackupRequest{FileName: "configuration.yaml"})
+	assert.Nil(t, err)
+	assert.Len(t, list.Backups, 2)
+
+	out, err := client.RestoreFile(ctx, &pb.BackupRequest{
+		FileName: "configuration.yaml",
+		Revision: list.Backups[1].Revision,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, pb.FileAction_UPDATED, out.Action)
+
+	contents, err := os.ReadFile(filepath.Join(root, "configuration.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, "version: 2\n", string(contents))
+
+	_, err = client.RestoreFile(ctx, &pb.BackupRequest{
+		FileName: "configuration.yaml",
+		Revision: "../../configuration.yaml",
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSendFilesTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	testcases := map[string]struct {
+		input    []*pb.File
+		expected []*pb.ProcessedFile
+		contents map[string]string
+		missing  []string
+	}{
+		"commit_files": {
+			input: []*pb.File{
+				{
+					FileName:       "configuration.yaml",
+					EncodedContent: encondeFileContent("homeassistant:\n  packages: !include_dir_named packages\n"),
+					Transactional:  true,
+				},
+				{
+					FileName:       "packages/lights.yaml",
+					EncodedContent: encondeFileContent("light:\n"),
+				},
+			},
+			expected: []*pb.ProcessedFile{
+				{
+					Processed:   true,
+					FileName:    "configuration.yaml",
+					Action:      pb.FileAction_UPDATED,
+					Transaction: pb.TransactionStatus_COMMITTED,
+				},
+				{
+					Processed:   true,
+					FileName:    "packages/lights.yaml",
+					Action:      pb.FileAction_CREATED,
+					Transaction: pb.TransactionStatus_COMMITTED,
+				},
+			},
+			contents: map[string]string{
+				"configuration.yaml":   "homeassistant:\n  packages: !include_dir_named packages\n",
+				"packages/lights.yaml": "light:\n",
+			},
+		},
+		"rollback_files": {
+			input: []*pb.File{
+				{
+					FileName:       "configuration.yaml",
+					EncodedContent: encondeFileContent("homeassistant:\n  packages: !include_dir_named packages\n"),
+					Transactional:  true,
+				},
+				{
+					FileName:       "packages/lights.yaml",
+					EncodedContent: encondeFileContent("light:\n"),
+				},
+				{
+					FileName:       "automations.yaml",
+					EncodedContent: encondeFileContent("[]\n"),
+					Mode:           pb.FileMode_CREATE_ONLY,
+				},
+			},
+			expected: []*pb.ProcessedFile{
+				{
+					FileName:    "configuration.yaml",
+					Error:       "transaction rolled back: automations.yaml: file already exists",
+					Transaction: pb.TransactionStatus_ROLLED_BACK,
+				},
+				{
+					FileName:    "packages/lights.yaml",
+					Error:       "transaction rolled back: automations.yaml: file already exists",
+					Transaction: pb.TransactionStatus_ROLLED_BACK,
+				},
+				{
+					FileName:    "automations.yaml",
+					Error:       "file already exists",
+					Transaction: pb.TransactionStatus_ROLLED_BACK,
+				},
+			},
+			contents: map[string]string{
+				"configuration.yaml": "homeassistant:\n",
+				"automations.yaml":   "",
+			},
+			missing: []string{"packages"},
+		},
+	}
+
+	for scenario, testcase := range testcases {
+		t.Run(scenario, func(t *testing.T) {
+			root := t.TempDir()
+			assert.Nil(t, os.WriteFile(filepath.Join(root, "configuration.yaml"), []byte("homeassistant:\n"), 0600))
+			assert.Nil(t, os.WriteFile(filepath.Join(root, "automations.yaml"), []byte(""), 0600))
+
+			client, closer := createFileClient(ctx, server.WithFileRoot(root), server.WithBackups(t.TempDir(), 0))
+			defer closer()
+
+			out, err := client.SendFiles(ctx)
+			assert.Nil(t, err)
+
+			for _, v := range testcase.input {
+				if err := out.Send(v); err != nil {
+					t.Errorf("Error while sending message: %v", err)
+				}
+			}
+
+			if err := out.CloseSend(); err != nil {
+				t.Errorf("Error closing stream: %v", err)
+			}
+
+			var outputs []*pb.ProcessedFile
+			for {
+				o, err := out.Recv()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+
+				assert.Nil(t, err)
+				outputs = append(outputs, o)
+			}
+
+			assert.Equal(t, len(testcase.expected), len(outputs))
+			for i, o := range outputs {
+				assert.Equal(t, testcase.expected[i].Processed, o.Processed)
+				assert.Equal(t, testcase.expected[i].FileName, o.FileName)
+				assert.Equal(t, testcase.expected[i].Action, o.Action)
+				assert.Equal(t, testcase.expected[i].Transaction, o.Transaction)
+				assert.Contains(t, o.Error, testcase.expected[i].Error)
+			}
+
+			for fileName, expected := range testcase.contents {
+				contents, err := os.ReadFile(filepath.Join(root, fileName))
+				assert.Nil(t, err)
+				assert.Equal(t, expected, string(contents))
+			}
+
+			for _, fileName := range testcase.missing {
+				assert.NoFileExists(t, filepath.Join(root, fileName))
+				assert.NoDirExists(t, filepath.Join(root, fileName))
+			}
+		})
+	}
+}
+
+func TestSendFileValidation(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(root, "configuration.yaml"), []byte("homeassistant:\n"), 0600))
+
+	client, closer := createFileClient(ctx, server.WithFileRoot(root), server.WithBackups(t.TempDir(), 0))
+	defer closer()
+
+	out, err := client.SendFile(ctx, &pb.File{
+		FileName:       "configuration.yaml",
+		EncodedContent: encondeFileContent("homeassistant:\n  packages: !include_dir_nmaed packages\n"),
+		Validate:       true,
+	})
+	assert.Nil(t, err)
+	assert.False(t, out.Processed)
+	assert.Contains(t, out.Error, "configuration.yaml:2:13: unknown tag !include_dir_nmaed")
+
+	contents, err := os.ReadFile(filepath.Join(root, "configuration.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, "homeassistant:\n", string(contents))
+
+	out, err = client.SendFile(ctx, &pb.File{
+		FileName:       "configuration.yaml",
+		EncodedContent: encondeFileContent("homeassistant:\n  packages: !include_dir_named packages\n"),
+		Validate:       true,
+	})
+	assert.Nil(t, err)
+	assert.True(t, out.Processed)
+	assert.Equal(t, pb.FileAction_UPDATED, out.Action)
+}
+
+func TestSendFileDryRun(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(root, "configuration.yaml"), []byte("homeassistant:\n"), 0600))
+
+	client, closer := createFileClient(ctx, server.WithFileRoot(root), server.WithBackups(t.TempDir(), 0))
+	defer closer()
+
+	testCases := map[string]struct {
+		input    *pb.File
+		expected *pb.ProcessedFile
+	}{
+		"update_file": {
+			input: &pb.File{
+				FileName:       "configuration.yaml",
+				EncodedContent: encondeFileContent("homeassistant:\n  name: Home\n"),
+				DryRun:         true,
+			},
+			expected: &pb.ProcessedFile{
+				FileName: "configuration.yaml",
+				Action:   pb.FileAction_UPDATED,
+				Diff: &pb.FileDiff{
+					UnifiedDiff: "--- a/configuration.yaml\n+++ b/configuration.yaml\n@@ -1 +1,2 @@\n homeassistant:\n+  name: Home\n",
+				},
+			},
+		},
+		"create_file": {
+			input: &pb.File{
+				FileName:       "packages/lights.yaml",
+				EncodedContent: encondeFileContent("light:\n"),
+				DryRun:         true,
+			},
+			expected: &pb.ProcessedFile{
+				FileName: "packages/lights.yaml",
+				Action:   pb.FileAction_CREATED,
+				Diff: &pb.FileDiff{
+					UnifiedDiff: "--- a/packages/lights.yaml\n+++ b/packages/lights.yaml\n@@ -0,0 +1 @@\n+light:\n",
+				},
+			},
+		},
+		"unchanged_file": {
+			input: &pb.File{
+				FileName:       "configuration.yaml",
+				EncodedContent: encondeFileContent("homeassistant:\n"),
+				DryRun:         true,
+			},
+			expected: &pb.ProcessedFile{
+				FileName: "configuration.yaml",
+				Action:   pb.FileAction_UNCHANGED,
+				Diff:     &pb.FileDiff{IsSame: true},
+			},
+		},
+		"create_existing_file": {
+			input: &pb.File{
+				FileName:       "configuration.yaml",
+				EncodedContent: encondeFileContent("homeassistant:\n  name: Home\n"),
+				Mode:           pb.FileMode_CREATE_ONLY,
+				DryRun:         true,
+			},
+			expected: &pb.ProcessedFile{
+				FileName: "configuration.yaml",
+				Error:    "file already exists",
+			},
+		},
+	}
+
+	for scenario, testcase := range testCases {
+		t.Run(scenario, func(t *testing.T) {
+			out, err := client.SendFile(ctx, testcase.input)
+			assert.Nil(t, err)
+			assert.True(t, out.DryRun)
+			assert.False(t, out.Processed)
+			assert.Equal(t, testcase.expected.FileName, out.FileName)
+			assert.Equal(t, testcase.expected.Action, out.Action)
+			assert.Contains(t, out.Error, testcase.expected.Error)
+
+			if testcase.expected.Diff != nil {
+				assert.Equal(t, testcase.expected.Diff.IsSame, out.Diff.IsSame)
+				assert.Equal(t, testcase.expected.Diff.UnifiedDiff, out.Diff.UnifiedDiff)
+			}
+		})
+	}
+
+	// Nothing is written by a dry run
+	contents, err := os.ReadFile(filepath.Join(root, "configuration.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, "homeassistant:\n", string(contents))
+	assert.NoDirExists(t, filepath.Join(root, "packages"))
+}
+
+func TestSendFilesTransactionDryRun(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(root, "configuration.yaml"), []byte("homeassistant:\n"), 0600))
+
+	client, closer := createFileClient(ctx, server.WithFileRoot(root), server.WithBackups(t.TempDir(), 0))
+	defer closer()
+
+	out, err := client.SendFiles(ctx)
+	assert.Nil(t, err)
+
+	files := []*pb.File{
+		{
+			FileName:       "packages/lights.yaml",
+			EncodedContent: encondeFileContent("light:\n"),
+			Transactional:  true,
+			DryRun:         true,
+		},
+		{
+			FileName:       "packages/lights.yaml",
+			EncodedContent: encondeFileContent("light:\n  - platform: group\n"),
+		},
+	}
+	for _, file := range files {
+		assert.Nil(t, out.Send(file))
+	}
+	assert.Nil(t, out.CloseSend())
+
+	var outputs []*pb.ProcessedFile
+	for {
+		o, err := out.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		outputs = append(outputs, o)
+	}
+
+	assert.Len(t, outputs, 2)
+	assert.Equal(t, pb.FileAction_CREATED, outputs[0].Action)
+	assert.Equal(t, pb.FileAction_UPDATED, outputs[1].Action)
+	assert.Contains(t, outputs[1].Diff.UnifiedDiff, "+  - platform: group\n")
+	for _, o := range outputs {
+		assert.True(t, o.DryRun)
+		assert.Equal(t, pb.TransactionStatus_COMMITTED, o.Transaction)
+	}
+
+	assert.NoDirExists(t, filepath.Join(root, "packages"))
+}
+
+func TestSendFilesTransactionLateDryRun(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+
+	client, closer := createFileClient(ctx, server.WithFileRoot(root), server.WithBackups(t.TempDir(), 0))
+	defer closer()
+
+	out, err := client.SendFiles(ctx)
+	assert.Nil(t, err)
+
+	// A dry run after the first file still plans the whole transaction
+	files := []*pb.File{
+		{
+			FileName:       "configuration.yaml",
+			EncodedContent: encondeFileContent("homeassistant:\n"),
+			Transactional:  true,
+		},
+		{
+			FileName:       "packages/lights.yaml",
+			EncodedContent: encondeFileContent("light:\n"),
+			DryRun:         true,
+		},
+	}
+	for _, file := range files {
+		assert.Nil(t, out.Send(file))
+	}
+	assert.Nil(t, out.CloseSend())
+
+	var outputs []*pb.ProcessedFile
+	for {
+		o, err := out.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		outputs = append(outputs, o)
+	}
+
+	assert.Len(t, outputs, 2)
+	for _, o := range outputs {
+		assert.True(t, o.DryRun)
+		assert.Equal(t, pb.FileAction_CREATED, o.Action)
+	}
+
+	assert.NoFileExists(t, filepath.Join(root, "configuration.yaml"))
+	assert.NoDirExists(t, filepath.Join(root, "packages"))
+}
@@ -0,0 +1,219 @@
+package test
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aacuadras/ha-utils/lib/filediff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffContents(t *testing.T) {
+	testCases := map[string]struct {
+		current  string
+		updated  string
+		expected filediff.Diff
+	}{
+		"same_contents": {
+			current:  "homeassistant:\n  name: Home\n",
+			updated:  "homeassistant:\n  name: Home\n",
+			expected: filediff.Diff{IsSame: true},
+		},
+		"changed_line": {
+			current: "homeassistant:\n  name: Home\n  unit_system: metric\n",
+			updated: "homeassistant:\n  name: Cabin\n  unit_system: metric\n",
+			expected: filediff.Diff{
+				Unified: "--- a/configuration.yaml\n+++ b/configuration.yaml\n" +
+					"@@ -1,3 +1,3 @@\n homeassistant:\n-  name: Home\n+  name: Cabin\n   unit_system: metric\n",
+				Hunks: []filediff.Hunk{
+					{
+						OldStart: 1, OldLines: 3, NewStart: 1, NewLines: 3,
+						Lines: []string{" homeassistant:", "-  name: Home", "+  name: Cabin", "   unit_system: metric"},
+					},
+				},
+			},
+		},
+		"new_file": {
+			current: "",
+			updated: "light:\n  - platform: hue\n",
+			expected: filediff.Diff{
+				Unified: "--- a/configuration.yaml\n+++ b/configuration.yaml\n" +
+					"@@ -0,0 +1,2 @@\n+light:\n+  - platform: hue\n",
+				Hunks: []filediff.Hunk{
+					{
+						OldStart: 0, OldLines: 0, NewStart: 1, NewLines: 2,
+						Lines: []string{"+light:", "+  - platform: hue"},
+					},
+				},
+			},
+		},
+		"missing_newline": {
+			current: "a\nb",
+			updated: "a\nb\n",
+			expected: filediff.Diff{
+				Unified: "--- a/configuration.yaml\n+++ b/configuration.yaml\n" +
+					"@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+				Hunks: []filediff.Hunk{
+					{
+						OldStart: 1, OldLines: 2, NewStart: 1, NewLines: 2,
+						Lines: []string{" a", "-b", `\ No newline at end of file`, "+b"},
+					},
+				},
+			},
+		},
+		"separate_hunks": {
+			current: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			updated: "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			expected: filediff.Diff{
+				Unified: "--- a/configuration.yaml\n+++ b/configuration.yaml\n" +
+					"@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+					"@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n",
+				Hunks: []filediff.Hunk{
+					{
+						OldStart: 1, OldLines: 4, NewStart: 1, NewLines: 4,
+						Lines: []string{"-1", "+one", " 2", " 3", " 4"},
+					},
+					{
+						OldStart: 9, OldLines: 4, NewStart: 9, NewLines: 4,
+						Lines: []string{" 9", " 10", " 11", "-12", "+twelve"},
+					},
+				},
+			},
+		},
+	}
+
+	for scenario, testcase := range testCases {
+		t.Run(scenario, func(t *testing.T) {
+			diff := filediff.DiffContents("configuration.yaml", []byte(testcase.current), []byte(testcase.updated))
+
+			assert.Equal(t, testcase.expected, diff)
+		})
+	}
+}
+
+func TestRootResolve(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+	assert.Nil(t, os.Symlink(outside, filepath.Join(dir, "escape")))
+	assert.Nil(t, os.Symlink(filepath.Join(outside, "secrets.yaml"), filepath.Join(dir, "secrets.yaml")))
+
+	root := filediff.NewRoot(dir)
+
+	testCases := map[string]struct {
+		fileName string
+		expected string
+		err      error
+	}{
+		"relative_file": {
+			fileName: "configuration.yaml",
+			expected: filepath.Join(dir, "configuration.yaml"),
+		},
+		"nested_missing_file": {
+			fileName: "./packages/lights.yaml",
+			expected: filepath.Join(dir, "packages", "lights.yaml"),
+		},
+		"absolute_file_inside_root": {
+			fileName: filepath.Join(dir, "automations.yaml"),
+			expected: filepath.Join(dir, "automations.yaml"),
+		},
+		"parent_traversal": {
+			fileName: "../../etc/passwd",
+			err:      filediff.ErrInvalidPath,
+		},
+		"empty_name": {
+			fileName: "",
+			err:      filediff.ErrInvalidPath,
+		},
+		"absolute_file_outside_root": {
+			fileName: filepath.Join(outside, "configuration.yaml"),
+			err:      filediff.ErrOutsideRoot,
+		},
+		"symlinked_directory_outside_root": {
+			fileName: "escape/configuration.yaml",
+			err:      filediff.ErrOutsideRoot,
+		},
+		"symlinked_file_outside_root": {
+			fileName: "secrets.yaml",
+			err:      filediff.ErrOutsideRoot,
+		},
+	}
+
+	for scenario, testcase := range testCases {
+		t.Run(scenario, func(t *testing.T) {
+			path, err := root.Resolve(testcase.fileName)
+
+			if testcase.err != nil {
+				assert.ErrorIs(t, err, testcase.err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, testcase.expected, path)
+			}
+		})
+	}
+}
+
+func TestTransactionRollback(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "configuration.yaml"), []byte("homeassistant:\n"), 0600))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "automations.yaml"), []byte("[]\n"), 0600))
+
+	root := filediff.NewRoot(dir)
+	root.SetBackupStore(filediff.NewBackupStore(t.TempDir(), 0))
+	contents := base64.StdEncoding.EncodeToString([]byte("homeassistant:\n  name: Home\n"))
+
+	t.Run("rolled_back", func(t *testing.T) {
+		tx := root.Begin()
+		assert.Nil(t, tx.Stage("configuration.yaml", contents, filediff.Upsert))
+		tx.Verify(func() error { return errors.New("invalid config") })
+
+		_, err := tx.Commit()
+		var txErr *filediff.TransactionError
+		assert.ErrorAs(t, err, &txErr)
+		assert.True(t, txErr.RolledBack())
+
+		restored, err := os.ReadFile(filepath.Join(dir, "configuration.yaml"))
+		assert.Nil(t, err)
+		assert.Equal(t, "homeassistant:\n", string(restored))
+
+		// The backup saved for the rolled back write is deleted
+		backups, err := root.ListBackups("configuration.yaml")
+		assert.Nil(t, err)
+		assert.Empty(t, backups)
+	})
+
+	t.Run("rollback_failed", func(t *testing.T) {
+		tx := root.Begin()
+		assert.Nil(t, tx.Stage("configuration.yaml", contents, filediff.Upsert))
+		assert.Nil(t, tx.Stage("automations.yaml", contents, filediff.Upsert))
+		tx.Verify(func() error {
+			// A directory in place of the file can't be replaced when it's restored
+			path := filepath.Join(dir, "automations.yaml")
+			assert.Nil(t, os.Remove(path))
+			assert.Nil(t, os.MkdirAll(filepath.Join(path, "keep"), 0700))
+			return errors.New("invalid config")
+		})
+
+		_, err := tx.Commit()
+		var txErr *filediff.TransactionError
+		assert.ErrorAs(t, err, &txErr)
+		assert.False(t, txErr.RolledBack())
+		assert.Len(t, txErr.RollbackErrs, 1)
+		assert.ErrorContains(t, err, "rollback failed: unable to restore automations.yaml")
+
+		// The other files are still restored and the backup of the file that wasn't restored is kept
+		restored, err := os.ReadFile(filepath.Join(dir, "configuration.yaml"))
+		assert.Nil(t, err)
+		assert.Equal(t, "homeassistant:\n", string(restored))
+
+		backups, err := root.ListBackups("configuration.yaml")
+		assert.Nil(t, err)
+		assert.Empty(t, backups)
+
+		backups, err = root.ListBackups("automations.yaml")
+		assert.Nil(t, err)
+		assert.Len(t, backups, 1)
+	})
+}
@@ -0,0 +1,67 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/aacuadras/ha-utils/lib/haconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateYAML(t *testing.T) {
+	testCases := map[string]struct {
+		contents string
+		expected []haconfig.Error
+	}{
+		"valid_config": {
+			contents: "homeassistant:\n" +
+				"  name: Home\n" +
+				"  packages: !include_dir_named packages\n" +
+				"automation: !include automations.yaml\n" +
+				"script: !include_dir_merge_named scripts\n" +
+				"http:\n" +
+				"  ssl_key: !secret ssl_key\n" +
+				"  server_host: !env_var SERVER_HOST 0.0.0.0\n",
+		},
+		"empty_file": {
+			contents: "",
+		},
+		"syntax_error": {
+			contents: "homeassistant:\n  name: Home\n unit_system: metric\n",
+			expected: []haconfig.Error{
+				{Line: 2, Message: "did not find expected key"},
+			},
+		},
+		"unknown_tag": {
+			contents: "automation: !includ automations.yaml\n",
+			expected: []haconfig.Error{
+				{Line: 1, Column: 13, Message: "unknown tag !includ"},
+			},
+		},
+		"tag_without_value": {
+			contents: "http:\n  ssl_key: !secret\n",
+			expected: []haconfig.Error{
+				{Line: 2, Column: 12, Message: "!secret is missing its value"},
+			},
+		},
+		"tag_on_mapping": {
+			contents: "automation: !include\n  file: automations.yaml\n",
+			expected: []haconfig.Error{
+				{Line: 1, Column: 13, Message: "!include must be applied to a value"},
+			},
+		},
+		"duplicate_key": {
+			contents: "light:\n  - platform: hue\nlight:\n  - platform: group\n",
+			expected: []haconfig.Error{
+				{Line: 3, Column: 1, Message: `duplicate key "light", first defined in line 1`},
+			},
+		},
+	}
+
+	for scenario, testcase := range testCases {
+		t.Run(scenario, func(t *testing.T) {
+			errs := haconfig.ValidateYAML([]byte(testcase.contents))
+
+			assert.Equal(t, testcase.expected, errs)
+		})
+	}
+}
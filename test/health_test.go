@@ -0,0 +1,171 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aacuadras/ha-utils/lib/docker"
+	"github.com/aacuadras/ha-utils/server"
+	"github.com/aacuadras/ha-utils/server/pb"
+	"github.com/docker/docker/api"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Starts the health and info servers, the docker server is checked with the fake runtime and the file server with
+// the root
+func createHealthServer(t *testing.T, fake *docker.Fake, root string, opts ...server.InfoOption) (*server.Health, *grpc.ClientConn) {
+	listener := bufconn.Listen(1024 * 1024)
+
+	health := server.NewHealth(server.WithDockerHealth(fake), server.WithFilesHealth(root))
+	s := grpc.NewServer()
+	healthpb.RegisterHealthServer(s, health)
+	pb.RegisterServerUtilsServer(s, server.NewInfoServer(fake, opts...))
+	go s.Serve(listener)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+		return listener.Dial()
+	}), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return health, conn
+}
+
+func TestHealthChecks(t *testing.T) {
+	ctx := context.Background()
+	fake := docker.NewFake()
+	root := t.TempDir()
+
+	health, conn := createHealthServer(t, fake, root)
+	client := healthpb.NewHealthClient(conn)
+
+	statuses := func() map[string]healthpb.HealthCheckResponse_ServingStatus {
+		out := map[string]healthpb.HealthCheckResponse_ServingStatus{}
+		for _, service := range []string{"", "DockerUtils", "FileUtils"} {
+			response, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+			if assert.Nil(t, err) {
+				out[service] = response.Status
+			}
+		}
+
+		return out
+	}
+
+	// Nothing is serving until it's checked
+	assert.Equal(t, map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"":            healthpb.HealthCheckResponse_NOT_SERVING,
+		"DockerUtils": healthpb.HealthCheckResponse_NOT_SERVING,
+		"FileUtils":   healthpb.HealthCheckResponse_NOT_SERVING,
+	}, statuses())
+
+	health.Update(ctx)
+	assert.Equal(t, map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"":            healthpb.HealthCheckResponse_SERVING,
+		"DockerUtils": healthpb.HealthCheckResponse_SERVING,
+		"FileUtils":   healthpb.HealthCheckResponse_SERVING,
+	}, statuses())
+
+	// The check doesn't leave files behind
+	entries, err := os.ReadDir(root)
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+
+	fake.SetPingError(errors.New("cannot connect to the docker daemon"))
+	health.Update(ctx)
+	assert.Equal(t, map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"":            healthpb.HealthCheckResponse_NOT_SERVING,
+		"DockerUtils": healthpb.HealthCheckResponse_NOT_SERVING,
+		"FileUtils":   healthpb.HealthCheckResponse_SERVING,
+	}, statuses())
+
+	fake.SetPingError(nil)
+	assert.Nil(t, os.Remove(root))
+	health.Update(ctx)
+	assert.Equal(t, map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"":            healthpb.HealthCheckResponse_NOT_SERVING,
+		"DockerUtils": healthpb.HealthCheckResponse_SERVING,
+		"FileUtils":   healthpb.HealthCheckResponse_NOT_SERVING,
+	}, statuses())
+
+	// The watchers are told when the server shuts down
+	watch, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "DockerUtils"})
+	assert.Nil(t, err)
+	response, err := watch.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, response.Status)
+
+	health.Shutdown()
+	response, err = watch.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, response.Status)
+}
+
+func TestHealthMonitor(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fake := docker.NewFake()
+	health := server.NewHealth(server.WithDockerHealth(fake), server.WithHealthInterval(10*time.Millisecond))
+	go health.Monitor(ctx)
+
+	check := func(status healthpb.HealthCheckResponse_ServingStatus) func() bool {
+		return func() bool {
+			response, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: "DockerUtils"})
+			return err == nil && response.Status == status
+		}
+	}
+
+	assert.Eventually(t, check(healthpb.HealthCheckResponse_SERVING), time.Second, 10*time.Millisecond)
+	fake.SetPingError(errors.New("cannot connect to the docker daemon"))
+	assert.Eventually(t, check(healthpb.HealthCheckResponse_NOT_SERVING), time.Second, 10*time.Millisecond)
+}
+
+func TestGetServerInfo(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+
+	_, conn := createHealthServer(t, docker.NewFake(), root,
+		server.WithVersion("1.2.0"),
+		server.WithRoots(root, "/srv/homeassistant"),
+		server.WithServices([]string{"docker", "files"}),
+	)
+	client := pb.NewServerUtilsClient(conn)
+
+	info, err := client.GetServerInfo(ctx, &pb.ServerInfoRequest{})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, "1.2.0", info.Version)
+	assert.Equal(t, api.DefaultVersion, info.DockerApiVersion)
+	assert.Equal(t, root, info.FileRoot)
+	assert.Equal(t, "/srv/homeassistant", info.HostRoot)
+	assert.Equal(t, []string{"docker", "files"}, info.Services)
+	assert.NotEmpty(t, info.Build.GoVersion)
+	assert.WithinDuration(t, time.Now(), info.StartedAt.AsTime(), time.Minute)
+	assert.GreaterOrEqual(t, info.UptimeSeconds, int64(0))
+}
+
+func TestServerInfoRelativeRoot(t *testing.T) {
+	_, conn := createHealthServer(t, docker.NewFake(), t.TempDir(), server.WithRoots(".", ""))
+
+	info, err := pb.NewServerUtilsClient(conn).GetServerInfo(context.Background(), &pb.ServerInfoRequest{})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Clean(wd), info.FileRoot)
+	assert.Empty(t, info.HostRoot)
+}
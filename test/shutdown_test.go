@@ -0,0 +1,137 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aacuadras/ha-utils/lib/docker"
+	"github.com/aacuadras/ha-utils/server"
+	"github.com/aacuadras/ha-utils/server/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Starts the docker and file servers with the shutdown interceptors, the docker server uses the runtime
+func createShutdownServer(t *testing.T, rt docker.Runtime, root string) (*grpc.Server, *server.Shutdown, *grpc.ClientConn) {
+	listener := bufconn.Listen(1024 * 1024)
+
+	shutdown := server.NewShutdown()
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(shutdown.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(shutdown.StreamInterceptor()),
+	)
+	pb.RegisterDockerUtilsServer(s, server.NewServer(rt))
+	pb.RegisterFileUtilsServer(s, server.NewFileServer(server.WithFileRoot(root)))
+	go s.Serve(listener)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+		return listener.Dial()
+	}), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return s, shutdown, conn
+}
+
+func TestShutdownCancelsContainerCalls(t *testing.T) {
+	ctx := context.Background()
+	fake := docker.NewFake()
+
+	s, shutdown, conn := createShutdownServer(t, fake, t.TempDir())
+	client := pb.NewDockerUtilsClient(conn)
+
+	_, err := client.StartContainer(ctx, &pb.ContainerRequest{ContainerName: "homeassistant"})
+	assert.Nil(t, err)
+
+	// Following the logs never ends on its own
+	logs, err := client.StreamLogs(ctx, &pb.LogsRequest{ContainerName: "homeassistant", Follow: true})
+	assert.Nil(t, err)
+	watch, err := client.WatchContainers(ctx, &pb.WatchRequest{})
+	assert.Nil(t, err)
+
+	// Give the server some time to start the calls
+	time.Sleep(100 * time.Millisecond)
+
+	start := time.Now()
+	assert.True(t, shutdown.Stop(s, 10*time.Second))
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// The followed logs end as if the client cancelled them
+	for err == nil {
+		_, err = logs.Recv()
+	}
+	assert.True(t, errors.Is(err, io.EOF), err)
+	_, err = watch.Recv()
+	assert.NotNil(t, err)
+}
+
+// Runtime whose containers take until the call is cancelled to stop
+type slowStopRuntime struct {
+	*docker.Fake
+	stopping chan struct{}
+}
+
+func (r slowStopRuntime) StopContainer(ctx context.Context, containerName string) error {
+	close(r.stopping)
+	<-ctx.Done()
+	return fmt.Errorf("unable to stop container %s: %w", containerName, ctx.Err())
+}
+
+func TestShutdownCancelsStoppingContainer(t *testing.T) {
+	ctx := context.Background()
+	rt := slowStopRuntime{Fake: docker.NewFake(), stopping: make(chan struct{})}
+
+	s, shutdown, conn := createShutdownServer(t, rt, t.TempDir())
+	client := pb.NewDockerUtilsClient(conn)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := client.StopContainer(ctx, &pb.ContainerRequest{ContainerName: "homeassistant"})
+		errs <- err
+	}()
+	<-rt.stopping
+
+	// The cancelled stop is reported to the client instead of bringing the server down
+	assert.True(t, shutdown.Stop(s, 5*time.Second))
+	assert.Equal(t, codes.Canceled, status.Code(<-errs))
+}
+
+func TestShutdownRollsBackTransactions(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+
+	s, shutdown, conn := createShutdownServer(t, docker.NewFake(), root)
+	client := pb.NewFileUtilsClient(conn)
+
+	// A written file finishes before the server stops
+	_, err := client.SendFile(ctx, &pb.File{FileName: "configuration.yaml", EncodedContent: encondeFileContent("a: 1\n")})
+	assert.Nil(t, err)
+
+	// The client never closes the transaction, so it's still being received when the drain timeout expires
+	stream, err := client.SendFiles(ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, stream.Send(&pb.File{
+		FileName:       "automations.yaml",
+		EncodedContent: encondeFileContent("[]\n"),
+		Transactional:  true,
+	}))
+	time.Sleep(100 * time.Millisecond)
+
+	assert.False(t, shutdown.Stop(s, 200*time.Millisecond))
+
+	_, err = os.Stat(filepath.Join(root, "configuration.yaml"))
+	assert.Nil(t, err)
+	_, err = os.Stat(filepath.Join(root, "automations.yaml"))
+	assert.True(t, os.IsNotExist(err))
+}